@@ -0,0 +1,17 @@
+package config
+
+var combatConfig CombatsConfig
+
+type CombatsConfig struct {
+	TagTime         int64    `yaml:"tag-time"`         // Tag time means the seconds a player stays in combat after hitting or being hit by another player
+	DamageWindow    int64    `yaml:"damage-window"`    // Damage window means the seconds a hit still counts for the kill or an assist
+	LoggerTime      int64    `yaml:"logger-time"`      // Logger time means the seconds the combat logger stays after the player disconnects while in combat
+	LogoutTime      int64    `yaml:"logout-time"`      // Logout time means the seconds a player must wait to leave safely using /logout
+	PvPTimer        int64    `yaml:"pvp-timer"`        // PvP timer means the seconds of PvP protection given to new and respawned players
+	BlockedCommands []string `yaml:"blocked-commands"` // Blocked commands means the commands that cannot be used while in combat
+}
+
+// CombatConfig returns the combat configuration.
+func CombatConfig() CombatsConfig {
+	return combatConfig
+}
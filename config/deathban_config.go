@@ -0,0 +1,13 @@
+package config
+
+var deathbanConfig DeathbansConfig
+
+type DeathbansConfig struct {
+	Time      int64 `yaml:"time"`       // Time means the seconds a player is banned from the map after dying
+	KickDelay int64 `yaml:"kick-delay"` // Kick delay means the seconds after dying before the player is kicked, so they can use a life
+}
+
+// DeathbanConfig returns the deathban configuration.
+func DeathbanConfig() DeathbansConfig {
+	return deathbanConfig
+}
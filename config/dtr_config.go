@@ -0,0 +1,28 @@
+package config
+
+var dtrConfig DtrConfig
+
+type DtrConfig struct {
+	PerMember float32 `yaml:"per-member"` // Per member means the DTR granted for each member of the team
+	Max       float32 `yaml:"max"`        // Max means the highest DTR a team can reach regardless of its member count
+	Min       float32 `yaml:"min"`        // Min means the lowest DTR a team can reach after losing members
+
+	FreezeTime int64 `yaml:"freeze-time"` // Freeze time means the seconds the DTR stays frozen after a member death
+
+	Regen struct { // This is the section for the regeneration values
+		Amount   float32 `yaml:"amount"`   // Amount means the DTR regenerated on each interval
+		Interval int64   `yaml:"interval"` // Interval means the seconds between each regeneration
+	} `yaml:"regen"`
+
+	Death struct { // This is the section for the DTR taken away on each member death
+		Overworld float32 `yaml:"overworld"` // Overworld means the DTR lost when the member dies in the overworld
+		Nether    float32 `yaml:"nether"`    // Nether means the DTR lost when the member dies in the nether
+		End       float32 `yaml:"end"`       // End means the DTR lost when the member dies in the end
+		KoTH      float32 `yaml:"koth"`      // KoTH means the DTR lost when the member dies inside the capture zone of a running KoTH
+	} `yaml:"death"`
+}
+
+// DTRConfig returns the DTR configuration.
+func DTRConfig() DtrConfig {
+	return dtrConfig
+}
@@ -0,0 +1,28 @@
+package config
+
+var killstreakConfig KillstreaksConfig
+
+type KillstreaksConfig struct {
+	Rewards []struct { // This is the section for the rewards given when a killstreak is reached
+		Streak int64 `yaml:"streak"` // Streak means the kills in a single life needed to get the reward
+
+		Items []struct { // Items means the items given to the player
+			Item  string `yaml:"item"`
+			Meta  int16  `yaml:"meta"`
+			Count int    `yaml:"count"`
+		} `yaml:"items"`
+
+		Effects []struct { // Effects means the potion effects given to the player
+			Id       int   `yaml:"id"`       // Id means the effect ID, like 1 for speed or 5 for strength
+			Level    int   `yaml:"level"`    // Level means the effect level, starting from 1
+			Duration int64 `yaml:"duration"` // Duration means the seconds the effect lasts
+		} `yaml:"effects"`
+
+		Broadcast bool `yaml:"broadcast"` // Broadcast means whether the killstreak is announced to the server
+	} `yaml:"rewards"`
+}
+
+// KillstreakConfig returns the killstreak configuration.
+func KillstreakConfig() KillstreaksConfig {
+	return killstreakConfig
+}
@@ -0,0 +1,30 @@
+package config
+
+var kothConfig KothConfig
+
+type KothConfig struct {
+	Points             int32   `yaml:"points"`              // Points means the points awarded to the team of the player who captures the KoTH
+	BroadcastIntervals []int64 `yaml:"broadcast-intervals"` // Broadcast intervals means the remaining seconds where the capture progress is broadcast
+
+	Rewards []struct { // This is the section for the items given to the player who captures the KoTH
+		Item  string `yaml:"item"`  // Item means the name of the item, for example 'minecraft:diamond'
+		Meta  int16  `yaml:"meta"`  // Meta means the metadata value of the item
+		Count int    `yaml:"count"` // Count means the amount of the item
+	} `yaml:"rewards"`
+
+	AnnounceBefore []int64                   `yaml:"announce-before"` // Announce before means the seconds before a scheduled KoTH where it is announced
+	Schedules      map[string][]KoTHSchedule `yaml:"schedules"`       // Schedules means the timetable of each KoTH by its name
+}
+
+// KoTHSchedule is an entry of the timetable of a KoTH.
+// It either starts every N hours since midnight, or at a time of the day, optionally only on a weekday.
+type KoTHSchedule struct {
+	Day        string `yaml:"day"`         // Day means the weekday, for example 'monday'. Empty means every day
+	Time       string `yaml:"time"`        // Time means the time of the day, for example '18:30'
+	EveryHours int    `yaml:"every-hours"` // Every hours means the hours between each start, it overrides day and time
+}
+
+// KoTHConfig returns the KoTH configuration.
+func KoTHConfig() KothConfig {
+	return kothConfig
+}
@@ -0,0 +1,12 @@
+package config
+
+var operatorConfig OperatorsConfig
+
+type OperatorsConfig struct {
+	XUIDs []string `yaml:"xuids"` // XUIDs means the players allowed to use the admin commands, the console is always allowed
+}
+
+// OperatorConfig returns the operators configuration.
+func OperatorConfig() OperatorsConfig {
+	return operatorConfig
+}
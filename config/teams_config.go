@@ -13,6 +13,30 @@ type TeamsConfig struct {
 		InvitedColour  string `yaml:"invited-colour"`  // Invited colour means the colour if is invited to the team
 		EnemyColour    string `yaml:"enemy-colour"`    // Enemy colour means the colour if is not member of the team
 	} `yaml:"display"`
+
+	Claim struct { // This is the section for the claim values
+		MinSize          int   `yaml:"min-size"`          // Min size means the minimum width and length of a claim in blocks
+		MaxSize          int   `yaml:"max-size"`          // Max size means the maximum width and length of a claim in blocks
+		PricePerBlock    int32 `yaml:"price-per-block"`   // Price per block means the balance charged for each block of the claim surface
+		RefundPercentage int32 `yaml:"refund-percentage"` // Refund percentage means the share of the claim cost refunded when it is unclaimed
+	} `yaml:"claim"`
+
+	Home struct { // This is the section for the home teleport values
+		Warmup      int64 `yaml:"warmup"`       // Warmup means the seconds a player waits before teleporting home
+		EnemyWarmup int64 `yaml:"enemy-warmup"` // Enemy warmup means the seconds a player waits before teleporting home from enemy land
+	} `yaml:"home"`
+
+	Territory struct { // This is the section for the territory values
+		WarzoneRadius int `yaml:"warzone-radius"` // Warzone radius means the distance in blocks from the spawn where unclaimed land is warzone
+	} `yaml:"territory"`
+
+	Leadership struct { // This is the section for the leadership values
+		InactiveDays int `yaml:"inactive-days"` // Inactive days means the days a leader can be offline before the leadership passes to another member, zero to disable it
+	} `yaml:"leadership"`
+
+	Raid struct { // This is the section for the raid values
+		Points int32 `yaml:"points"` // Points means the points taken from the raided team and given to the raiding team
+	} `yaml:"raid"`
 }
 
 // TeamConfig returns the team configuration.
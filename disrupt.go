@@ -7,5 +7,5 @@ import (
 )
 
 var Log logrus.Logger
-var SRV server.Server
+var SRV *server.Server
 var Mongo *mongo.Client
@@ -2,8 +2,11 @@ package main
 
 import (
     "github.com/aabstractt/aurial/handler"
+    "github.com/bitrule/disrupt"
     "github.com/bitrule/disrupt/service"
     tcmd "github.com/bitrule/disrupt/team/cmd"
+    ucmd "github.com/bitrule/disrupt/user/cmd"
+    uhandler "github.com/bitrule/disrupt/user/handler"
     "github.com/df-mc/dragonfly/server"
     "github.com/df-mc/dragonfly/server/cmd"
     "github.com/df-mc/dragonfly/server/player"
@@ -30,20 +33,99 @@ func main() {
         tcmd.TeamSystemCreateCmd{},
         tcmd.TeamCreateCmd{},
         tcmd.TeamInviteCmd{},
+        tcmd.TeamKickCmd{},
         tcmd.TeamDisbandCmd{},
         tcmd.TeamLeaveCmd{},
         tcmd.TeamAcceptCmd{},
+        tcmd.TeamClaimCmd{},
+        tcmd.TeamUnclaimCmd{},
+        tcmd.TeamUnclaimAllCmd{},
+        tcmd.TeamFriendlyFireCmd{},
+        tcmd.TeamSetHomeCmd{},
+        tcmd.TeamHomeCmd{},
+        tcmd.TeamPromoteCmd{},
+        tcmd.TeamDemoteCmd{},
+        tcmd.TeamLeaderCmd{},
+        tcmd.TeamPermsCmd{},
+        tcmd.TeamPermsSetCmd{},
     ))
 
+    cmd.Register(cmd.New(
+        "koth",
+        "Manage the KoTHs and view their schedule.",
+        nil,
+        tcmd.KoTHScheduleCmd{},
+        tcmd.KoTHNextCmd{},
+        tcmd.KoTHCreateCmd{},
+        tcmd.KoTHSetZoneCmd{},
+        tcmd.KoTHSetDurationCmd{},
+        tcmd.KoTHStartCmd{},
+        tcmd.KoTHStopCmd{},
+        tcmd.KoTHListCmd{},
+    ))
+
+    cmd.Register(cmd.New(
+        "logout",
+        "Leave the server safely after a countdown.",
+        nil,
+        ucmd.LogoutCmd{},
+    ))
+
+    cmd.Register(cmd.New(
+        "pvp",
+        "Manage your PvP protection.",
+        nil,
+        ucmd.PvPEnableCmd{},
+    ))
+
+    cmd.Register(cmd.New(
+        "lives",
+        "View and use your lives to revive from a deathban.",
+        nil,
+        ucmd.LivesCmd{},
+        ucmd.LivesUseCmd{},
+        ucmd.LivesGiveCmd{},
+    ))
+
+    cmd.Register(cmd.New(
+        "revive",
+        "Use a life to revive a deathbanned player.",
+        nil,
+        ucmd.ReviveCmd{},
+    ))
+
+    uhandler.RegisterJoinHandler()
+    uhandler.RegisterQuitHandler()
+    uhandler.RegisterSafeZoneHandler()
+    uhandler.RegisterFriendlyFireHandler()
+    uhandler.RegisterPvPTimerHandler()
+    uhandler.RegisterDeathHandler()
+    uhandler.RegisterWandHandler()
+    uhandler.RegisterProtectionHandler()
+    uhandler.RegisterHurtHandler()
+    uhandler.RegisterCombatHandler()
+    uhandler.RegisterMoveHandler()
+    uhandler.RegisterCountdownHandler()
+
+    srv := server.New()
+    // The services look up online players through the server, so it must be set before ticking them
+    disrupt.SRV = srv
+
+    // The default worlds are loaded by the server, the rest are loaded lazily by the world service
+    service.World().Register(srv.World())
+    service.World().Register(srv.Nether())
+    service.World().Register(srv.End())
+
     ticker := time.NewTicker(50 * time.Millisecond)
     go func() {
         for range ticker.C {
             service.Team().DoTick()
+            service.KoTH().DoTick()
             service.User().DoTick()
+            service.CombatLogger().DoTick()
         }
     }()
 
-    srv := server.New()
     srv.Accept(func(p *player.Player) {
         handler.Hook(p)
     })
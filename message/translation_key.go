@@ -1,21 +1,35 @@
 package message
 
 var (
-	ErrPlayerNotFound       = translationKey{"player.not_found", "player"}            // This means the target player was not found
-	ErrTeamNotFound         = translationKey{"team.not_found", "team"}                // This means the target team was not found
-	ErrTeamAlreadyExists    = translationKey{"team.already_exists", "team"}           // This means a team with the same name already exists
-	ErrPlayerAlreadyInTeam  = translationKey{"team.player_already_in_team", "player"} // This means the target player is already in a team
-	ErrSelfAlreadyInTeam    = translationKey{"team.self_already_in_team"}             // This means the sender is already in a team
-	ErrPlayerNotInTeam      = translationKey{"team.player_not_in_team", "player"}     // This means the target player is not in a team
-	ErrPlayerNotTeamMember  = translationKey{"team.player_not_team_member", "player"} // This means the target player is not a member of the team
-	ErrPlayerAlreadyMember  = translationKey{"team.player_already_member", "player"}  // This means the target player is already a member of the team
-	ErrPlayerAlreadyInvited = translationKey{"team.player_already_invited", "player"} // This means the target player is already invited to the team
-	ErrPlayerHighestRole    = translationKey{"team.player_highest_role"}              // This means the target player has the highest role in the team
-	ErrSelfNotInTeam        = translationKey{"team.self_not_in_team"}                 // This means the sender is not in a team
-	ErrSelfNotLeader        = translationKey{"team.self_not_leader"}                  // This means the sender is not the leader of the team
-	ErrSelfNotOfficer       = translationKey{"team.self_not_officer"}                 // This means the sender is not an officer of the team
-	ErrSelfNotInvited       = translationKey{"team.self_not_invited", "team"}         // This means the sender is not invited to the team
-	ErrCannotUseOnSelf      = translationKey{"team.cannot_use_on_self"}               // This means the sender cannot use the command on themselves
+	ErrPlayerNotFound           = translationKey{"player.not_found", "player"}                 // This means the target player was not found
+	ErrTeamNotFound             = translationKey{"team.not_found", "team"}                     // This means the target team was not found
+	ErrTeamAlreadyExists        = translationKey{"team.already_exists", "team"}                // This means a team with the same name already exists
+	ErrPlayerAlreadyInTeam      = translationKey{"team.player_already_in_team", "player"}      // This means the target player is already in a team
+	ErrSelfAlreadyInTeam        = translationKey{"team.self_already_in_team"}                  // This means the sender is already in a team
+	ErrPlayerNotInTeam          = translationKey{"team.player_not_in_team", "player"}          // This means the target player is not in a team
+	ErrPlayerNotTeamMember      = translationKey{"team.player_not_team_member", "player"}      // This means the target player is not a member of the team
+	ErrPlayerAlreadyMember      = translationKey{"team.player_already_member", "player"}       // This means the target player is already a member of the team
+	ErrPlayerAlreadyInvited     = translationKey{"team.player_already_invited", "player"}      // This means the target player is already invited to the team
+	ErrPlayerHighestRole        = translationKey{"team.player_highest_role"}                   // This means the target player has the highest role in the team
+	ErrSelfNotInTeam            = translationKey{"team.self_not_in_team"}                      // This means the sender is not in a team
+	ErrSelfNotLeader            = translationKey{"team.self_not_leader"}                       // This means the sender is not the leader of the team
+	ErrInvalidPermission        = translationKey{"team.invalid_permission", "action"}          // This means the action is not part of the permission matrix
+	ErrSelfNoPermission         = translationKey{"team.self_no_permission", "role"}            // This means the sender role is lower than the role the team requires for the action
+	ErrSelfNotInvited           = translationKey{"team.self_not_invited", "team"}              // This means the sender is not invited to the team
+	ErrCannotUseOnSelf          = translationKey{"team.cannot_use_on_self"}                    // This means the sender cannot use the command on themselves
+	ErrFriendlyFire             = translationKey{"team.friendly_fire_protected", "player"}     // This means the sender cannot hurt a member of their team or an ally
+	ErrTeamNoHQ                 = translationKey{"team.no_hq"}                                 // This means the team of the sender has no home set
+	ErrTeamHomeEnd              = translationKey{"team.home_end"}                              // This means the sender cannot teleport home from the end
+	ErrTeamHomeCombat           = translationKey{"team.home_combat", "remaining"}              // This means the sender cannot teleport home while in combat
+	ErrTeamHomeAlreadyWarming   = translationKey{"team.home_already_warming"}                  // This means the sender is already teleporting home
+	ErrTeamHomeCancelled        = translationKey{"team.home_cancelled"}                        // This means the teleport home of the sender was cancelled
+	SuccessSelfTeamHomeWarmup   = translationKey{"team.success_self_home_warmup", "remaining"} // This means the sender started teleporting home
+	SuccessSelfTeamHomeTeleport = translationKey{"team.success_self_home_teleported"}          // This means the sender was teleported home
+	ErrPlayerCannotPromote      = translationKey{"team.player_cannot_promote", "player"}       // This means the target player cannot be promoted any further
+	ErrPlayerCannotDemote       = translationKey{"team.player_cannot_demote", "player"}        // This means the target player cannot be demoted any further
+	ErrSelfRoleTooLow           = translationKey{"team.self_role_too_low", "player"}           // This means the sender role is not high enough to change the role of the target player
+	ErrLandProtected            = translationKey{"team.land_protected", "team"}                // This means the sender cannot modify the land of another team
+	ErrSafeZoneAttack           = translationKey{"territory.safe_zone_attack"}                 // This means the sender cannot attack because they or the target are inside a safe zone
 
 	SuccessTeamCreated     = translationKey{"team.success_broadcast_team_created", "player", "team"} // This means a team was successfully created
 	SuccessSelfTeamCreated = translationKey{"team.success_self_team_created", "team"}                // This means the sender successfully created a team
@@ -33,6 +47,125 @@ var (
 	SuccessSelfTeamMemberKicked = translationKey{"team.success_self_team_member_kicked", "player"} // This means the sender successfully kicked the target player from the team
 	SuccessTeamKick             = translationKey{"team.success_team_kick", "player", "sender"}     // This means the target player was successfully kicked from the team
 	SuccessSelfTeamKicked       = translationKey{"team.success_self_team_kicked", "team"}          // This means the target player was successfully kicked from the team
+
+	SuccessTeamMemberPromoted = translationKey{"team.success_team_member_promoted", "player", "role", "sender"} // This means a member of the team was promoted
+	SuccessTeamMemberDemoted  = translationKey{"team.success_team_member_demoted", "player", "role", "sender"}  // This means a member of the team was demoted
+
+	SuccessTeamLeaderChanged   = translationKey{"team.success_team_leader_changed", "player", "sender"}   // This means the leader passed the leadership to a member
+	SuccessTeamLeaderSucceeded = translationKey{"team.success_team_leader_succeeded", "player", "leader"} // This means a member became the leader because the leader was inactive
+
+	TeamPermissionsHeader        = translationKey{"team.permissions_header", "team"}                                  // This means the header of the permission matrix of the team
+	TeamPermissionsEntry         = translationKey{"team.permissions_entry", "action", "role"}                         // This means an action of the permission matrix and the lowest role allowed to do it
+	SuccessTeamPermissionUpdated = translationKey{"team.success_team_permission_updated", "player", "action", "role"} // This means a player changed the lowest role allowed to do an action
+
+	SuccessTeamHQUpdated = translationKey{"team.success_team_hq_updated", "player", "x", "y", "z"} // This means a player updated the home of the team
+
+	SuccessTeamFriendlyFireEnabled  = translationKey{"team.success_team_friendly_fire_enabled", "player"}  // This means a player enabled the friendly fire of the team
+	SuccessTeamFriendlyFireDisabled = translationKey{"team.success_team_friendly_fire_disabled", "player"} // This means a player disabled the friendly fire of the team
+
+	ActionTeamBroadcastChat     = translationKey{"team.broadcast_chat", "player", "message"} // This means a message sent to the team chat
+	SuccessSelfTeamChatEnabled  = translationKey{"team.success_self_chat_enabled"}           // This means the sender started talking in the team chat
+	SuccessSelfTeamChatDisabled = translationKey{"team.success_self_chat_disabled"}          // This means the sender stopped talking in the team chat
+
+	BroadcastTeamDTRFull         = translationKey{"dtr.broadcast_full", "dtr"}                            // This means the team DTR reached its max value
+	BroadcastTeamDTRRegenerating = translationKey{"dtr.broadcast_regenerating", "dtr"}                    // This means the team DTR started to regenerate
+	BroadcastTeamRaidable        = translationKey{"dtr.broadcast_raidable", "dtr"}                        // This means the team DTR is at or below zero and the team can be raided
+	BroadcastTeamRaidedBy        = translationKey{"dtr.broadcast_raided_by", "team", "points"}            // This means the team went raidable and lost points to the raiding team
+	BroadcastTeamRaiding         = translationKey{"dtr.broadcast_raiding", "team", "points"}              // This means the team made another team raidable and earned points
+	BroadcastTeamMemberDeath     = translationKey{"dtr.broadcast_member_death", "player", "dtr", "regen"} // This means a team member died and the team lost DTR
+
+	BroadcastKoTHStarted   = translationKey{"koth.broadcast_started", "koth"}                    // This means a KoTH has started
+	BroadcastKoTHStopped   = translationKey{"koth.broadcast_stopped", "koth"}                    // This means a KoTH was stopped without a winner
+	BroadcastKoTHCapping   = translationKey{"koth.broadcast_capping", "koth", "remaining"}       // This means someone started to capture a KoTH
+	BroadcastKoTHKnocked   = translationKey{"koth.broadcast_knocked", "player", "koth"}          // This means the capper was knocked off the KoTH
+	BroadcastKoTHRemaining = translationKey{"koth.broadcast_remaining", "koth", "remaining"}     // This means the remaining time of the capture
+	BroadcastKoTHCaptured  = translationKey{"koth.broadcast_captured", "player", "team", "koth"} // This means a KoTH was captured
+	BroadcastKoTHUpcoming  = translationKey{"koth.broadcast_upcoming", "koth", "remaining"}      // This means a scheduled KoTH starts soon
+	SuccessSelfKoTHCapping = translationKey{"koth.success_self_capping", "koth"}                 // This means the sender started to capture a KoTH
+
+	ErrKoTHNoneScheduled      = translationKey{"koth.none_scheduled"}                                      // This means there is no KoTH in the timetables
+	SuccessKoTHScheduleHeader = translationKey{"koth.success_schedule_header"}                             // This means the header of the KoTH timetable
+	SuccessKoTHScheduleEntry  = translationKey{"koth.success_schedule_entry", "koth", "date", "remaining"} // This means an entry of the KoTH timetable
+	SuccessKoTHNext           = translationKey{"koth.success_next", "koth", "date", "remaining"}           // This means the next scheduled KoTH
+
+	ErrKoTHNotFound        = translationKey{"koth.not_found", "koth"}      // This means the target KoTH was not found
+	ErrKoTHInvalidDuration = translationKey{"koth.invalid_duration"}       // This means the duration of the KoTH is not valid
+	ErrKoTHNoZone          = translationKey{"koth.no_zone", "koth"}        // This means the KoTH does not have a capture zone
+	ErrKoTHZoneWorld       = translationKey{"koth.zone_world", "koth"}     // This means the capture zone of the KoTH cannot be set in the world of the sender
+	ErrKoTHAlreadyActive   = translationKey{"koth.already_active", "koth"} // This means the KoTH is already running
+	ErrKoTHNotActive       = translationKey{"koth.not_active", "koth"}     // This means the KoTH is not running
+	ErrKoTHNoneActive      = translationKey{"koth.none_active"}            // This means there is no KoTH running
+
+	SuccessKoTHZoneSet     = translationKey{"koth.success_zone_set", "koth"}                          // This means the capture zone of the KoTH was set
+	SuccessKoTHDurationSet = translationKey{"koth.success_duration_set", "koth", "duration"}          // This means the duration of the KoTH was set
+	SuccessKoTHListEntry   = translationKey{"koth.success_list_entry", "koth", "remaining", "capper"} // This means an entry of the running KoTHs
+
+	TerritoryWilderness          = translationKey{"territory.wilderness"}                         // This means the name of the land nobody claimed
+	TerritoryWarzone             = translationKey{"territory.warzone"}                            // This means the name of the land nobody claimed around the spawn
+	SuccessSelfTerritoryLeaving  = translationKey{"territory.success_self_leaving", "territory"}  // This means the sender left a territory
+	SuccessSelfTerritoryEntering = translationKey{"territory.success_self_entering", "territory"} // This means the sender entered a territory
+
+	SuccessSelfCombatTagged     = translationKey{"combat.success_self_tagged", "remaining"}            // This means the sender was tagged in combat
+	ErrCombatBlockedCommand     = translationKey{"combat.blocked_command", "remaining"}                // This means the sender cannot use the command while in combat
+	ErrCombatSafeZone           = translationKey{"combat.safe_zone", "remaining"}                      // This means the sender cannot enter a safe zone while in combat
+	CombatLoggerNameTag         = translationKey{"combat.logger_name_tag", "player"}                   // This means the name tag of the combat logger NPC
+	BroadcastCombatLoggerKilled = translationKey{"combat.broadcast_logger_killed", "player", "killer"} // This means a combat logger was killed by a player
+	BroadcastCombatLoggerDied   = translationKey{"combat.broadcast_logger_died", "player"}             // This means a combat logger died without a killer
+	ErrCombatLoggerKilled       = translationKey{"combat.logger_killed"}                               // This means the combat logger of the sender was killed while they were offline
+	BroadcastCombatLogged       = translationKey{"combat.broadcast_logged", "player"}                  // This means a player logged out while in combat
+
+	SuccessSelfPvPTimerExpired = translationKey{"pvp.success_self_expired"}       // This means the PvP protection of the sender expired
+	SuccessSelfPvPEnabled      = translationKey{"pvp.success_self_enabled"}       // This means the sender removed their PvP protection
+	ErrPvPNotProtected         = translationKey{"pvp.not_protected"}              // This means the sender does not have PvP protection
+	ErrPvPSelfProtected        = translationKey{"pvp.self_protected"}             // This means the sender cannot do this while they have PvP protection
+	ErrPvPPlayerProtected      = translationKey{"pvp.player_protected", "player"} // This means the target player has PvP protection
+	ErrPvPEnemyLand            = translationKey{"pvp.enemy_land", "team"}         // This means the sender cannot enter enemy land while they have PvP protection
+
+	BroadcastPlayerKilled = translationKey{"death.broadcast_killed", "player", "kills", "killer", "killer_kills", "weapon"} // This means a player was killed by another player
+	BroadcastPlayerDied   = translationKey{"death.broadcast_died", "player", "kills"}                                       // This means a player died without a killer
+	DeathWeaponFists      = translationKey{"death.weapon_fists"}                                                            // This means the weapon name when the killer had nothing in hand
+
+	SuccessSelfKillstreak = translationKey{"killstreak.success_self_reached", "streak"}        // This means the sender reached a rewarded killstreak
+	BroadcastKillstreak   = translationKey{"killstreak.broadcast_reached", "player", "streak"} // This means a player reached a rewarded killstreak
+
+	ErrSelfDeathbanned      = translationKey{"deathban.self_deathbanned", "remaining", "delay"}    // This means the sender was deathbanned after dying
+	ErrDeathbanKick         = translationKey{"deathban.kick", "remaining"}                         // This means the disconnect message of a deathbanned player
+	ErrSelfNotDeathbanned   = translationKey{"deathban.not_deathbanned"}                           // This means the sender is not deathbanned
+	ErrPlayerNotDeathbanned = translationKey{"deathban.player_not_deathbanned", "player"}          // This means the target player is not deathbanned
+	ErrNoLives              = translationKey{"deathban.no_lives"}                                  // This means the sender does not have any lives
+	ErrLivesInvalidAmount   = translationKey{"deathban.invalid_amount"}                            // This means the amount of lives is not valid
+	SuccessSelfLives        = translationKey{"deathban.success_self_lives", "lives"}               // This means the lives of the sender
+	SuccessSelfRevived      = translationKey{"deathban.success_self_revived", "lives"}             // This means the sender used a life to revive themselves
+	SuccessPlayerRevived    = translationKey{"deathban.success_player_revived", "player", "lives"} // This means the sender used a life to revive the target player
+	SuccessLivesGiven       = translationKey{"deathban.success_lives_given", "amount", "player"}   // This means the sender gave lives to the target player
+
+	SuccessSelfLogoutStarted = translationKey{"logout.success_self_started", "remaining"} // This means the sender started the logout countdown
+	SuccessSelfLoggedOut     = translationKey{"logout.success_self_logged_out"}           // This means the sender logged out safely
+	ErrLogoutAlreadyRunning  = translationKey{"logout.already_running"}                   // This means the sender is already logging out
+	ErrLogoutCancelled       = translationKey{"logout.cancelled"}                         // This means the logout countdown of the sender was cancelled
+
+	ScoreboardTitle     = translationKey{"scoreboard.title"}                   // This means the title of the scoreboard
+	ScoreboardCombatTag = translationKey{"scoreboard.combat_tag", "remaining"} // This means the scoreboard line of the combat tag
+	ScoreboardPvPTimer  = translationKey{"scoreboard.pvp_timer", "remaining"}  // This means the scoreboard line of the PvP timer
+	ScoreboardHome      = translationKey{"scoreboard.home", "remaining"}       // This means the scoreboard line of the teleport home warmup
+	ScoreboardLogout    = translationKey{"scoreboard.logout", "remaining"}     // This means the scoreboard line of the logout countdown
+
+	ErrClaimNoSelection      = translationKey{"claim.no_selection"}                          // This means the sender has not selected both corners of the claim
+	ErrClaimOtherWorld       = translationKey{"claim.other_world"}                           // This means the selection is in a different world than the sender
+	ErrClaimTooSmall         = translationKey{"claim.too_small", "size"}                     // This means the selection is smaller than the minimum claim size
+	ErrClaimTooLarge         = translationKey{"claim.too_large", "size"}                     // This means the selection is larger than the maximum claim size
+	ErrClaimOverlaps         = translationKey{"claim.overlaps", "team"}                      // This means the selection overlaps the land of another team
+	ErrClaimNotEnoughBalance = translationKey{"claim.not_enough_balance", "cost", "balance"} // This means the team cannot afford the claim
+
+	ErrUnclaimNotInLand     = translationKey{"claim.not_in_land"}     // This means the sender is not standing in the land of their team
+	ErrUnclaimNoLand        = translationKey{"claim.no_land"}         // This means the team has no land to unclaim
+	ErrUnclaimHQOutsideLand = translationKey{"claim.hq_outside_land"} // This means the team HQ would end up outside the remaining land
+
+	SuccessSelfClaimWandReceived = translationKey{"claim.success_self_wand_received"}                          // This means the sender received the claim wand
+	SuccessSelfClaimCornerSet    = translationKey{"claim.success_self_corner_set", "corner", "x", "z"}         // This means the sender selected a corner of the claim
+	SuccessTeamLandClaimed       = translationKey{"claim.success_team_land_claimed", "player", "cost"}         // This means the team successfully claimed land
+	SuccessTeamLandUnclaimed     = translationKey{"claim.success_team_land_unclaimed", "player", "refund"}     // This means the team successfully unclaimed land
+	SuccessTeamAllLandUnclaimed  = translationKey{"claim.success_team_all_land_unclaimed", "player", "refund"} // This means the team successfully unclaimed all their land
 )
 
 type translationKey []string
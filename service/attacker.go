@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// AttackerOf returns the player who caused the damage, either hitting the victim
+// or shooting the projectile that hit them.
+func AttackerOf(src world.DamageSource) (*player.Player, bool) {
+	switch src := src.(type) {
+	case entity.AttackDamageSource:
+		p, ok := src.Attacker.(*player.Player)
+		return p, ok
+	case entity.ProjectileDamageSource:
+		p, ok := src.Owner.(*player.Player)
+		return p, ok
+	}
+
+	return nil, false
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/bitrule/disrupt"
+	"github.com/df-mc/dragonfly/server/player/chat"
+)
+
+// Broadcast sends the message to every player on the server.
+func Broadcast(msg string) {
+	if _, err := chat.Global.WriteString(msg); err != nil {
+		disrupt.Log.WithError(err).Error("failed to broadcast message")
+	}
+}
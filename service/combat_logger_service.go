@@ -0,0 +1,198 @@
+package service
+
+import (
+	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+	"time"
+)
+
+// combatLogger is the NPC that takes the place of a player who disconnected while in combat.
+type combatLogger struct {
+	player.NopHandler
+
+	xuid string
+	name string
+
+	npc       *player.Player
+	expiresAt time.Time
+}
+
+// HandleHurt prevents the team members and allies of the player, and players with PvP protection,
+// from hurting the NPC.
+func (l *combatLogger) HandleHurt(ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	attacker, ok := AttackerOf(src)
+	if !ok {
+		return
+	}
+
+	if u := userService.LookupByXUID(attacker.XUID()); u != nil && u.PvPProtected() {
+		ctx.Cancel()
+
+		attacker.Message(message.ErrPvPSelfProtected.Build())
+	} else if !teamService.CanDamage(attacker.XUID(), l.xuid) {
+		ctx.Cancel()
+
+		attacker.Message(message.ErrFriendlyFire.Build(l.name))
+	}
+}
+
+// HandleDeath counts the death of the NPC as a real death of the player.
+// The NPC drops the player's items, the team loses DTR and the killer earns the kill.
+func (l *combatLogger) HandleDeath(src world.DamageSource, _ *bool) {
+	combatLoggerService.remove(l.xuid)
+
+	u := userService.LookupByXUID(l.xuid)
+	if u == nil {
+		disrupt.Log.WithField("player", l.name).Error("combat logger killed but the player has no user")
+
+		return
+	}
+
+	// The items are dropped by the NPC, so the player must lose them when rejoining
+	u.SetCombatLoggerKilled(true)
+	u.Tracker().IncDeaths()
+	u.Tracker().ResetStreak()
+
+	var killerTeam *team.PlayerTeam
+
+	if killer, ok := AttackerOf(src); ok {
+		if ku := userService.LookupByXUID(killer.XUID()); ku != nil {
+			userService.AddKill(ku)
+
+			userService.SaveAsync(ku)
+		}
+
+		killerTeam = teamService.LookupByMember(killer.XUID())
+
+		Broadcast(message.BroadcastCombatLoggerKilled.Build(l.name, killer.Name()))
+	} else {
+		Broadcast(message.BroadcastCombatLoggerDied.Build(l.name))
+	}
+
+	userService.Deathban(u)
+
+	t := teamService.LookupByMember(l.xuid)
+	if t == nil {
+		return
+	}
+
+	wasRaidable := t.DTR().Raidable()
+	teamService.ApplyDeathPenalty(t, l.name, l.npc.World(), l.npc.Position())
+
+	// The team of the killer is the one that made the team raidable
+	if !wasRaidable && t.DTR().Raidable() && killerTeam != nil && killerTeam != t {
+		teamService.Raid(t, killerTeam)
+	}
+}
+
+type CombatLoggerService struct {
+	mu      sync.Mutex               // Protects loggers
+	loggers map[string]*combatLogger // XUID -> Combat logger
+}
+
+// Spawn spawns a combat logger holding the inventory of the player where they disconnected.
+func (s *CombatLoggerService) Spawn(p *player.Player) {
+	npc := player.New(p.Name(), p.Skin(), p.Position())
+	npc.SetNameTag(message.CombatLoggerNameTag.Build(p.Name()))
+
+	for slot, it := range p.Inventory().Slots() {
+		_ = npc.Inventory().SetItem(slot, it)
+	}
+
+	npc.Armour().Set(p.Armour().Helmet(), p.Armour().Chestplate(), p.Armour().Leggings(), p.Armour().Boots())
+	npc.SetHeldItems(p.HeldItems())
+
+	if missing := npc.MaxHealth() - p.Health(); missing > 0 {
+		npc.Hurt(missing, entity.VoidDamageSource{})
+	}
+
+	l := &combatLogger{
+		xuid:      p.XUID(),
+		name:      p.Name(),
+		npc:       npc,
+		expiresAt: time.Now().Add(time.Duration(config.CombatConfig().LoggerTime) * time.Second),
+	}
+	npc.Handle(l)
+
+	s.mu.Lock()
+	s.loggers[p.XUID()] = l
+	s.mu.Unlock()
+
+	p.World().AddEntity(npc)
+}
+
+// Rejoin removes the combat logger of the player and syncs the player with it.
+// If the combat logger was killed, the player loses the items it dropped and is sent to the spawn.
+func (s *CombatLoggerService) Rejoin(p *player.Player, u *user.User) {
+	if l, ok := s.remove(p.XUID()); ok {
+		p.Teleport(l.npc.Position())
+
+		if missing := p.Health() - l.npc.Health(); missing > 0 {
+			p.Hurt(missing, entity.VoidDamageSource{})
+		}
+
+		_ = l.npc.Close()
+	}
+
+	if !u.CombatLoggerKilled() {
+		return
+	}
+
+	p.Inventory().Clear()
+	p.Armour().Clear()
+	p.Heal(p.MaxHealth(), entity.FoodHealingSource{})
+	p.Teleport(p.World().Spawn().Vec3Middle())
+
+	p.Message(message.ErrCombatLoggerKilled.Build())
+
+	u.SetCombatLoggerKilled(false)
+
+	userService.SaveAsync(u)
+}
+
+// DoTick removes the combat loggers that were not killed in time.
+// This function should be called every tick.
+func (s *CombatLoggerService) DoTick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for xuid, l := range s.loggers {
+		if time.Now().Before(l.expiresAt) {
+			continue
+		}
+
+		delete(s.loggers, xuid)
+
+		_ = l.npc.Close()
+	}
+}
+
+// remove removes the combat logger of the player from the service, ok is false if the player has none.
+func (s *CombatLoggerService) remove(xuid string) (*combatLogger, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.loggers[xuid]
+	if ok {
+		delete(s.loggers, xuid)
+	}
+
+	return l, ok
+}
+
+// CombatLogger returns the combat logger service.
+func CombatLogger() *CombatLoggerService {
+	return combatLoggerService
+}
+
+var combatLoggerService = &CombatLoggerService{
+	loggers: make(map[string]*combatLogger),
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+	"time"
+)
+
+// AddKill gives the kill to the user and increases their killstreak, handing out the rewards of the
+// streak reached if the killer is online.
+func (s *UserService) AddKill(u *user.User) {
+	u.Tracker().IncKills()
+
+	streak := u.Tracker().IncStreak()
+
+	p, ok := disrupt.SRV.PlayerByXUID(u.XUID())
+	if !ok {
+		return
+	}
+
+	for _, reward := range config.KillstreakConfig().Rewards {
+		if reward.Streak != streak {
+			continue
+		}
+
+		for _, ri := range reward.Items {
+			it, ok := world.ItemByName(ri.Item, ri.Meta)
+			if !ok {
+				disrupt.Log.WithField("item", ri.Item).Error("killstreak reward item not found")
+
+				continue
+			}
+
+			if _, err := p.Inventory().AddItem(item.NewStack(it, ri.Count)); err != nil {
+				// Drop the reward on the ground if the inventory is full
+				p.Drop(item.NewStack(it, ri.Count))
+			}
+		}
+
+		for _, re := range reward.Effects {
+			t, ok := effect.ByID(re.Id)
+			if !ok {
+				disrupt.Log.WithField("effect", re.Id).Error("killstreak reward effect not found")
+
+				continue
+			}
+
+			lt, ok := t.(effect.LastingType)
+			if !ok {
+				disrupt.Log.WithField("effect", re.Id).Error("killstreak reward effect is not lasting")
+
+				continue
+			}
+
+			p.AddEffect(effect.New(lt, re.Level, time.Duration(re.Duration)*time.Second))
+		}
+
+		p.Message(message.SuccessSelfKillstreak.Build(strconv.FormatInt(streak, 10)))
+
+		if reward.Broadcast {
+			Broadcast(message.BroadcastKillstreak.Build(u.Name(), strconv.FormatInt(streak, 10)))
+		}
+	}
+}
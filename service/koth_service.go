@@ -0,0 +1,255 @@
+package service
+
+import (
+	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upcoming represents the next time a scheduled KoTH starts.
+type Upcoming struct {
+	Name string
+	At   time.Time
+}
+
+type KoTHService struct {
+	mu       sync.Mutex // Protects lastTick
+	lastTick time.Time
+}
+
+// Upcoming returns the next start of every entry in the KoTH timetables, sorted by the closest first.
+func (s *KoTHService) Upcoming() []Upcoming {
+	return upcomingAfter(time.Now())
+}
+
+// Next returns the closest scheduled KoTH, ok is false if there is no KoTH scheduled.
+func (s *KoTHService) Next() (Upcoming, bool) {
+	if upcoming := s.Upcoming(); len(upcoming) > 0 {
+		return upcoming[0], true
+	}
+
+	return Upcoming{}, false
+}
+
+// DoTick announces the upcoming KoTHs and starts them once their scheduled time is reached.
+// This function should be called every tick.
+func (s *KoTHService) DoTick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.lastTick.IsZero() {
+		s.lastTick = now
+
+		return
+	}
+
+	from := s.lastTick
+	s.lastTick = now
+
+	for _, u := range upcomingAfter(from) {
+		if u.At.After(now) {
+			for _, seconds := range config.KoTHConfig().AnnounceBefore {
+				if at := u.At.Add(-time.Duration(seconds) * time.Second); at.After(from) && !at.After(now) {
+					Broadcast(message.BroadcastKoTHUpcoming.Build(u.Name, (time.Duration(seconds) * time.Second).String()))
+				}
+			}
+
+			continue
+		}
+
+		st, ok := teamService.LookupByName(u.Name).(*team.SystemTeam)
+		if !ok {
+			disrupt.Log.WithField("koth", u.Name).Error("scheduled KoTH team not found")
+
+			continue
+		}
+
+		if kt, ok := st.KoTH(); !ok {
+			disrupt.Log.WithField("koth", u.Name).Error("scheduled team is not a KoTH")
+		} else {
+			kt.Start()
+		}
+	}
+}
+
+// kothEnv resolves the KoTH teams, their worlds and the players capturing them from the services.
+type kothEnv struct{}
+
+// Name returns the name of the KoTH team, the team ID if it does not exist anymore.
+func (kothEnv) Name(teamId string) string {
+	if t := teamService.LookupById(teamId); t != nil {
+		return t.Tracker().Name()
+	}
+
+	return teamId
+}
+
+// World returns the world of the KoTH team land the capture zone is in, nil if it is outside the land.
+func (kothEnv) World(teamId string, bbox cube.BBox) *world.World {
+	t := teamService.LookupById(teamId)
+	if t == nil {
+		return nil
+	}
+
+	return zoneWorld(t, bbox)
+}
+
+// Capable returns true if the player is a member of a player team.
+func (kothEnv) Capable(xuid string) bool {
+	return teamService.LookupByMember(xuid) != nil
+}
+
+// UserName returns the name of the user, empty if it is unknown.
+func (kothEnv) UserName(xuid string) string {
+	if u := userService.LookupByXUID(xuid); u != nil {
+		return u.Name()
+	}
+
+	return ""
+}
+
+// AwardPoints gives the points to the team of the player and returns its name.
+func (kothEnv) AwardPoints(xuid string, points int32) (string, bool) {
+	pt := teamService.LookupByMember(xuid)
+	if pt == nil {
+		return "", false
+	}
+
+	pt.Tracker().AddPoints(points)
+	teamService.SaveAsync(pt)
+
+	return pt.Tracker().Name(), true
+}
+
+// ZoneWorld returns the world of the KoTH land the capture zone is in, ok is false if the zone is
+// not set or is outside the land of the KoTH, so the KoTH would never run.
+func (s *KoTHService) ZoneWorld(st *team.SystemTeam) (*world.World, bool) {
+	kt, ok := st.KoTH()
+	if !ok || kt.BBox() == (cube.BBox{}) {
+		return nil, false
+	}
+
+	w := zoneWorld(st, kt.BBox())
+
+	return w, w != nil
+}
+
+// SetZone sets the capture zone of the KoTH and claims the land around it, replacing the claim of the previous zone.
+// Returns false if the zone would not be resolved back to the world, so the KoTH would never run.
+// The caller is responsible for saving the team after setting the zone.
+func (s *KoTHService) SetZone(st *team.SystemTeam, w *world.World, zone cube.BBox) bool {
+	kt, ok := st.KoTH()
+	if !ok || worldService.Lookup(w.Name(), w.Dimension()) != w {
+		return false
+	}
+
+	if old := kt.BBox(); old != (cube.BBox{}) {
+		for key, bBoxes := range st.Tracker().Cuboids() {
+			for _, bbox := range bBoxes {
+				if sameColumns(bbox, old) {
+					teamService.Unclaim(st, key, bbox)
+				}
+			}
+		}
+	}
+
+	r := w.Range()
+	teamService.Claim(st, w, cube.Box(zone.Min()[0], float64(r.Min()), zone.Min()[2], zone.Max()[0], float64(r.Max()+1), zone.Max()[2]))
+
+	kt.SetBBox(zone)
+
+	return true
+}
+
+// sameColumns returns true if both bounding boxes cover the same columns, whatever their height is.
+// The land claimed for a capture zone covers the same columns as the zone, from the bottom to the top of the world.
+func sameColumns(a, b cube.BBox) bool {
+	return a.Min()[0] == b.Min()[0] && a.Min()[2] == b.Min()[2] && a.Max()[0] == b.Max()[0] && a.Max()[2] == b.Max()[2]
+}
+
+// zoneWorld returns the world of the team land the bounding box intersects with, nil if there is none.
+func zoneWorld(t team.Team, bbox cube.BBox) *world.World {
+	for key, bBoxes := range t.Tracker().Cuboids() {
+		for _, other := range bBoxes {
+			if !other.IntersectsWith(bbox) {
+				continue
+			}
+
+			if w := worldService.Lookup(key.Name, key.Dim); w != nil {
+				return w
+			}
+		}
+	}
+
+	return nil
+}
+
+// upcomingAfter returns the first start after the given time of every entry in the KoTH timetables,
+// sorted by the closest first.
+func upcomingAfter(after time.Time) []Upcoming {
+	var upcoming []Upcoming
+	for name, entries := range config.KoTHConfig().Schedules {
+		for _, entry := range entries {
+			if at, ok := nextOccurrence(entry, after); ok {
+				upcoming = append(upcoming, Upcoming{Name: name, At: at})
+			}
+		}
+	}
+
+	slices.SortFunc(upcoming, func(a, b Upcoming) int {
+		return a.At.Compare(b.At)
+	})
+
+	return upcoming
+}
+
+// nextOccurrence returns the first time after the given one that matches the timetable entry.
+// ok is false if the entry is not valid.
+func nextOccurrence(entry config.KoTHSchedule, after time.Time) (time.Time, bool) {
+	midnight := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, after.Location())
+
+	if entry.EveryHours > 0 {
+		at := midnight
+		for !at.After(after) {
+			at = at.Add(time.Duration(entry.EveryHours) * time.Hour)
+		}
+
+		// The interval starts again from midnight every day
+		if next := midnight.AddDate(0, 0, 1); at.After(next) {
+			at = next
+		}
+
+		return at, true
+	}
+
+	clock, err := time.Parse("15:04", entry.Time)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	at := midnight.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	for i := 0; i < 8; i++ {
+		if at.After(after) && (entry.Day == "" || strings.EqualFold(at.Weekday().String(), entry.Day)) {
+			return at, true
+		}
+
+		at = at.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, false
+}
+
+// KoTH returns the KoTH service.
+func KoTH() *KoTHService {
+	return kothService
+}
+
+var kothService = &KoTHService{}
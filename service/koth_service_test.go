@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitrule/disrupt/config"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	// 2024-01-01 is a monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		entry config.KoTHSchedule
+		after time.Time
+		want  time.Time
+	}{
+		{"every hours", config.KoTHSchedule{EveryHours: 6}, at(1, 7, 0), at(1, 12, 0)},
+		{"every hours on the start", config.KoTHSchedule{EveryHours: 6}, at(1, 12, 0), at(1, 18, 0)},
+		{"every hours restart at midnight", config.KoTHSchedule{EveryHours: 5}, at(1, 22, 0), at(2, 0, 0)},
+		{"every hours override the time", config.KoTHSchedule{EveryHours: 6, Time: "08:00"}, at(1, 1, 0), at(1, 6, 0)},
+		{"daily later today", config.KoTHSchedule{Time: "18:30"}, at(1, 10, 0), at(1, 18, 30)},
+		{"daily tomorrow", config.KoTHSchedule{Time: "18:30"}, at(1, 18, 30), at(2, 18, 30)},
+		{"weekday", config.KoTHSchedule{Day: "friday", Time: "18:00"}, at(1, 10, 0), at(5, 18, 0)},
+		{"weekday case", config.KoTHSchedule{Day: "Friday", Time: "18:00"}, at(1, 10, 0), at(5, 18, 0)},
+		{"weekday next week", config.KoTHSchedule{Day: "monday", Time: "18:00"}, at(1, 19, 0), at(8, 18, 0)},
+	}
+
+	for _, test := range tests {
+		got, ok := nextOccurrence(test.entry, test.after)
+		if !ok || !got.Equal(test.want) {
+			t.Errorf("%s: expected %s, got %s %t", test.name, test.want, got, ok)
+		}
+	}
+}
+
+func TestNextOccurrenceInvalid(t *testing.T) {
+	after := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, entry := range []config.KoTHSchedule{
+		{Time: "25:99"},
+		{Time: ""},
+		{Day: "funday", Time: "18:00"},
+	} {
+		if got, ok := nextOccurrence(entry, after); ok {
+			t.Errorf("%+v: expected no occurrence, got %s", entry, got)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/bitrule/disrupt/config"
 	"github.com/bitrule/disrupt/message"
 	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/world"
@@ -15,7 +17,10 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/text"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +28,11 @@ import (
 
 var IDKey = "_id"
 
+var (
+	WildernessTerritory = "wilderness" // Territory ID of the land nobody claimed
+	WarzoneTerritory    = "warzone"    // Territory ID of the land nobody claimed around the spawn
+)
+
 type TeamService struct {
 	col *mongo.Collection // Repository
 
@@ -32,11 +42,14 @@ type TeamService struct {
 	teamIdsMu sync.RWMutex      // Protects teamIds
 	teamIds   map[string]string // Team name as lower case -> Team ID
 
-	teamsPerChunkMu sync.RWMutex                           // Protects teamsPerChunk
-	teamsPerChunk   map[string]map[world.ChunkPos][]string // World name -> Chunk position -> Team ID
+	teamsPerChunkMu sync.RWMutex                                  // Protects teamsPerChunk
+	teamsPerChunk   map[team.WorldKey]map[world.ChunkPos][]string // World -> Chunk position -> Team ID
 
 	membersMu sync.RWMutex      // Protects members
 	members   map[string]string // XUID -> Team ID
+
+	successionMu   sync.Mutex // Protects lastSuccession
+	lastSuccession time.Time  // Last time the leaders were checked for inactivity
 }
 
 // LookupByMember looks up a team by a member's XUID.
@@ -85,7 +98,7 @@ func (s *TeamService) LookupByChunk(w *world.World, vec3 mgl64.Vec3) []team.Team
 	s.teamsPerChunkMu.RLock()
 	defer s.teamsPerChunkMu.RUnlock()
 
-	chunks, ok := s.teamsPerChunk[w.Name()]
+	chunks, ok := s.teamsPerChunk[team.WorldKeyOf(w)]
 	if !ok || chunks == nil {
 		return nil
 	}
@@ -115,7 +128,7 @@ func (s *TeamService) LookupAt(w *world.World, vec3 mgl64.Vec3) team.Team {
 	}
 
 	for _, t := range teamsPerChunk {
-		bBoxes, ok := t.Tracker().Cuboids()[w.Name()]
+		bBoxes, ok := t.Tracker().Cuboids()[team.WorldKeyOf(w)]
 		if !ok || bBoxes == nil {
 			continue
 		}
@@ -130,6 +143,167 @@ func (s *TeamService) LookupAt(w *world.World, vec3 mgl64.Vec3) team.Team {
 	return nil
 }
 
+// TerritoryAt returns the ID of the territory at the Vec3, which is the ID of the team that claimed the land.
+// If nobody claimed it, it returns WarzoneTerritory when the Vec3 is within the warzone radius around the
+// world spawn, or WildernessTerritory otherwise.
+func (s *TeamService) TerritoryAt(w *world.World, vec3 mgl64.Vec3) string {
+	if t := s.LookupAt(w, vec3); t != nil {
+		return t.Tracker().Id()
+	}
+
+	spawn := w.Spawn()
+	radius := float64(config.TeamConfig().Territory.WarzoneRadius)
+	if math.Abs(vec3.X()-float64(spawn.X())) <= radius && math.Abs(vec3.Z()-float64(spawn.Z())) <= radius {
+		return WarzoneTerritory
+	}
+
+	return WildernessTerritory
+}
+
+// SafeZoneAt returns true if the Vec3 is inside the land of a team flagged as safe zone.
+func (s *TeamService) SafeZoneAt(w *world.World, vec3 mgl64.Vec3) bool {
+	t := s.LookupAt(w, vec3)
+	if t == nil {
+		return false
+	}
+
+	safe, ok := t.Tracker().Option(team.SafeZoneKeyOption).(bool)
+
+	return ok && safe
+}
+
+// TerritoryName returns the name of the territory as it should be displayed to the player.
+// Also, see TerritoryAt and DisplayName.
+func (s *TeamService) TerritoryName(p *player.Player, id string) string {
+	switch id {
+	case WildernessTerritory:
+		return message.TerritoryWilderness.Build()
+	case WarzoneTerritory:
+		return message.TerritoryWarzone.Build()
+	}
+
+	if t := s.LookupById(id); t != nil {
+		return s.DisplayName(p, t)
+	}
+
+	// The team was disbanded while the player was inside its land
+	return message.TerritoryWilderness.Build()
+}
+
+// SystemTeams returns all the system teams.
+func (s *TeamService) SystemTeams() []*team.SystemTeam {
+	s.teamsMu.RLock()
+	defer s.teamsMu.RUnlock()
+
+	var teams []*team.SystemTeam
+	for _, t := range s.teams {
+		if st, ok := t.(*team.SystemTeam); ok {
+			teams = append(teams, st)
+		}
+	}
+
+	return teams
+}
+
+// LookupIntersecting looks up a team that has a cuboid intersecting with the given bounding box.
+// It only checks the teams indexed in the chunks covered by the bounding box, and skips the teams
+// the ignored function returns true for, if it is not nil.
+func (s *TeamService) LookupIntersecting(w *world.World, bbox cube.BBox, ignored func(team.Team) bool) team.Team {
+	for _, pos := range chunksWithin(bbox) {
+		for _, t := range s.LookupByChunk(w, mgl64.Vec3{float64(pos[0] << 4), 0, float64(pos[1] << 4)}) {
+			if ignored != nil && ignored(t) {
+				continue
+			}
+
+			for _, other := range t.Tracker().Cuboids()[team.WorldKeyOf(w)] {
+				if other.IntersectsWith(bbox) {
+					return t
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Claim adds the bounding box to the team's land and registers it into the chunks it covers.
+// The caller is responsible for saving the team after claiming.
+func (s *TeamService) Claim(t team.Team, w *world.World, bbox cube.BBox) {
+	key := team.WorldKeyOf(w)
+	t.Tracker().AddCuboid(key, bbox)
+
+	s.index(t.Tracker().Id(), key, bbox)
+}
+
+// Unclaim removes the bounding box from the team's land and unregisters it from the chunks it covered.
+// The caller is responsible for saving the team after unclaiming.
+func (s *TeamService) Unclaim(t team.Team, key team.WorldKey, bbox cube.BBox) {
+	if !t.Tracker().RemoveCuboid(key, bbox) {
+		return
+	}
+
+	s.unindex(t.Tracker().Id(), key, bbox)
+
+	// Chunks shared with the remaining cuboids must stay registered
+	for _, other := range t.Tracker().Cuboids()[key] {
+		s.index(t.Tracker().Id(), key, other)
+	}
+}
+
+// index registers the team ID into every chunk covered by the bounding box.
+func (s *TeamService) index(id string, key team.WorldKey, bbox cube.BBox) {
+	s.teamsPerChunkMu.Lock()
+	defer s.teamsPerChunkMu.Unlock()
+
+	chunks, ok := s.teamsPerChunk[key]
+	if !ok {
+		chunks = make(map[world.ChunkPos][]string)
+		s.teamsPerChunk[key] = chunks
+	}
+
+	for _, pos := range chunksWithin(bbox) {
+		if !slices.Contains(chunks[pos], id) {
+			chunks[pos] = append(chunks[pos], id)
+		}
+	}
+}
+
+// unindex unregisters the team ID from every chunk covered by the bounding box.
+func (s *TeamService) unindex(id string, key team.WorldKey, bbox cube.BBox) {
+	s.teamsPerChunkMu.Lock()
+	defer s.teamsPerChunkMu.Unlock()
+
+	chunks, ok := s.teamsPerChunk[key]
+	if !ok {
+		return
+	}
+
+	for _, pos := range chunksWithin(bbox) {
+		if i := slices.Index(chunks[pos], id); i != -1 {
+			chunks[pos] = slices.Delete(chunks[pos], i, i+1)
+		}
+
+		if len(chunks[pos]) == 0 {
+			delete(chunks, pos)
+		}
+	}
+}
+
+// chunksWithin returns the positions of all the chunks covered by the bounding box.
+func chunksWithin(bbox cube.BBox) []world.ChunkPos {
+	minX, minZ := int32(math.Floor(bbox.Min()[0]))>>4, int32(math.Floor(bbox.Min()[2]))>>4
+	maxX, maxZ := int32(math.Ceil(bbox.Max()[0])-1)>>4, int32(math.Ceil(bbox.Max()[2])-1)>>4
+
+	chunks := make([]world.ChunkPos, 0, (maxX-minX+1)*(maxZ-minZ+1))
+	for x := minX; x <= maxX; x++ {
+		for z := minZ; z <= maxZ; z++ {
+			chunks = append(chunks, world.ChunkPos{x, z})
+		}
+	}
+
+	return chunks
+}
+
 // Delete deletes a team by its ID.
 func (s *TeamService) Delete(id string) {
 	s.teamsMu.Lock()
@@ -162,6 +336,13 @@ func (s *TeamService) CacheMember(xuid, teamId string) {
 
 // cache caches a team.
 func (s *TeamService) cache(t team.Team) {
+	// The KoTH tick cannot import the services, so they are given to it instead
+	if st, ok := t.(*team.SystemTeam); ok {
+		if kt, ok := st.KoTH(); ok {
+			kt.SetEnv(kothEnv{})
+		}
+	}
+
 	s.teamsMu.Lock()
 	s.teams[t.Tracker().Id()] = t
 	s.teamsMu.Unlock()
@@ -201,6 +382,116 @@ func (s *TeamService) DisplayColour(p *player.Player, t *team.PlayerTeam) string
 	return config.TeamConfig().Display.EnemyColour
 }
 
+// ApplyDeathPenalty takes DTR away from the team after a member death and freezes its regeneration.
+// The DTR lost depends on the world where the member died, or if they died inside the capture zone of a running KoTH.
+func (s *TeamService) ApplyDeathPenalty(t *team.PlayerTeam, name string, w *world.World, pos mgl64.Vec3) {
+	dtrConfig := config.DTRConfig()
+
+	var amount float32
+	if st, ok := s.LookupAt(w, pos).(*team.SystemTeam); ok {
+		if kt, ok := st.KoTH(); ok && kt.Active() && kt.BBox().Vec3Within(pos) {
+			amount = dtrConfig.Death.KoTH
+		}
+	}
+
+	if amount == 0 {
+		switch w.Dimension() {
+		case world.Nether:
+			amount = dtrConfig.Death.Nether
+		case world.End:
+			amount = dtrConfig.Death.End
+		default:
+			amount = dtrConfig.Death.Overworld
+		}
+	}
+
+	t.DTR().Decrease(amount, dtrConfig.Min)
+	t.DTR().UpdateRemaining(dtrConfig.FreezeTime)
+
+	t.Broadcast(message.BroadcastTeamMemberDeath.Build(
+		name,
+		strconv.FormatFloat(float64(t.DTR().Value()), 'f', 2, 32),
+		t.DTR().Remaining().Round(time.Second).String(),
+	))
+}
+
+// ResolveHQ returns the HQ of the team with its world loaded, ok is false if the team has no HQ or its
+// world no longer exists. The world is looked up the first time the HQ is used, if it is not found the HQ is
+// marked invalid, so the team keeps working without it.
+func (s *TeamService) ResolveHQ(t *team.PlayerTeam) (team.HQ, bool) {
+	hq := t.HQ()
+	if !hq.Loaded() {
+		return hq, false
+	}
+
+	if !hq.Resolved() {
+		resolved := hq.WithWorld(worldService.Load(hq.WorldName(), hq.Dimension()))
+
+		// The HQ may be set again while its world is loaded, the new one is resolved instead
+		if !t.CompareAndSetHQ(hq, resolved) {
+			return s.ResolveHQ(t)
+		}
+
+		if hq = resolved; !hq.Valid() {
+			disrupt.Log.WithField("team", t.Tracker().Name()).Warnf("the world '%s' of the HQ no longer exists", hq.WorldName())
+		}
+	}
+
+	return hq, hq.Valid()
+}
+
+// CanDamage returns true if the attacker is allowed to hurt the victim.
+// Members of the same team and of allied teams cannot hurt each other unless the victim's team turned
+// friendly fire on.
+func (s *TeamService) CanDamage(attacker, victim string) bool {
+	vt, at := s.LookupByMember(victim), s.LookupByMember(attacker)
+	if vt == nil || at == nil || (vt != at && !vt.IsAlly(at.Tracker().Id())) {
+		return true
+	}
+
+	ff, ok := vt.Tracker().Option(team.FriendlyFireKeyOption).(bool)
+
+	return ok && ff
+}
+
+// CanModify returns true if the player is allowed to modify the land at the position, also returns the
+// team that owns the land, if any. The key is the tracker option that allows anyone to do it.
+// Members of a player team can always modify its land, and enemies can only do it once the team is raidable.
+func (s *TeamService) CanModify(p *player.Player, pos cube.Pos, key string) (team.Team, bool) {
+	t := s.LookupAt(p.World(), pos.Vec3Centre())
+	if t == nil {
+		return nil, true
+	}
+
+	if v, ok := t.Tracker().Option(key).(bool); ok && v {
+		return t, true
+	}
+
+	pt, ok := t.(*team.PlayerTeam)
+	if !ok {
+		return t, false
+	}
+
+	return t, pt.Member(p.XUID()) != team.Undefined || pt.DTR().Raidable()
+}
+
+// Raid takes points away from the raided team and gives them to the raiding team.
+// This function should be called when the raided team goes raidable.
+func (s *TeamService) Raid(raided, raiding *team.PlayerTeam) {
+	points := min(config.TeamConfig().Raid.Points, max(raided.Tracker().Points(), 0))
+
+	raided.Tracker().AddPoints(-points)
+	raiding.Tracker().AddPoints(points)
+
+	s.SaveAsync(raided)
+	s.SaveAsync(raiding)
+
+	pointsStr := strconv.Itoa(int(points))
+
+	raided.Broadcast(message.BroadcastTeamRaidedBy.Build(raiding.Tracker().Name(), pointsStr))
+	raiding.Broadcast(message.BroadcastTeamRaiding.Build(raided.Tracker().Name(), pointsStr))
+}
+
 // Create creates a team.
 // Use this function into a goroutine to prevent blocking the main thread.
 func (s *TeamService) Create(p *player.Player, t team.Team) {
@@ -255,7 +546,12 @@ func (s *TeamService) Save(t team.Team) error {
 		return errors.New("missing repository")
 	}
 
-	r, err := s.col.UpdateOne(context.TODO(), bson.M{IDKey: t.Tracker().Id()}, bson.M{"$set": t.Marshal()})
+	body, err := t.Marshal()
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal the team: "), err)
+	}
+
+	r, err := s.col.UpdateOne(context.TODO(), bson.M{IDKey: t.Tracker().Id()}, bson.M{"$set": body}, options.Update().SetUpsert(true))
 	if err != nil {
 		return err
 	}
@@ -267,19 +563,124 @@ func (s *TeamService) Save(t team.Team) error {
 	return nil
 }
 
-// DoTick ticks all the system teams.
+// SaveAsync saves the team in the background and logs the error if it fails.
+func (s *TeamService) SaveAsync(t team.Team) {
+	go func() {
+		if err := s.Save(t); err != nil {
+			disrupt.Log.WithError(err).Errorf("failed to save the team %s", t.Tracker().Name())
+		}
+	}()
+}
+
+// DoTick ticks all the system teams and the DTR of the player teams.
 // This function should be called every tick.
 func (s *TeamService) DoTick() {
-	s.teamsMu.RLock()
-	defer s.teamsMu.RUnlock()
+	s.tickSuccession()
 
+	// The teams are ticked without the lock, because the ticks look up other teams
+	s.teamsMu.RLock()
+	teams := make([]team.Team, 0, len(s.teams))
 	for _, t := range s.teams {
+		teams = append(teams, t)
+	}
+	s.teamsMu.RUnlock()
+
+	for _, t := range teams {
 		if st, ok := t.(*team.SystemTeam); ok {
 			st.DoTick()
+		} else if pt, ok := t.(*team.PlayerTeam); ok {
+			pt.DoTick()
 		}
 	}
 }
 
+// tickSuccession passes the leadership of the teams whose leader has been inactive for too long
+// to their highest ranked active member. The leaders are checked once per minute.
+func (s *TeamService) tickSuccession() {
+	days := config.TeamConfig().Leadership.InactiveDays
+	if days <= 0 {
+		return
+	}
+
+	s.successionMu.Lock()
+	if time.Since(s.lastSuccession) < time.Minute {
+		s.successionMu.Unlock()
+
+		return
+	}
+
+	s.lastSuccession = time.Now()
+	s.successionMu.Unlock()
+
+	inactivity := time.Duration(days) * 24 * time.Hour
+	isInactive := func(u *user.User) bool {
+		return inactive(u, inactivity)
+	}
+
+	s.teamsMu.RLock()
+	var teams []*team.PlayerTeam
+	for _, t := range s.teams {
+		if pt, ok := t.(*team.PlayerTeam); ok {
+			teams = append(teams, pt)
+		}
+	}
+	s.teamsMu.RUnlock()
+
+	for _, t := range teams {
+		leader := userService.LookupByXUID(t.Ownership())
+		if leader == nil || !isInactive(leader) {
+			continue
+		}
+
+		successor := successorOf(t, isInactive)
+		if successor == nil || !t.TransferOwnership(successor.XUID(), team.Officer) {
+			continue
+		}
+
+		t.Broadcast(message.SuccessTeamLeaderSucceeded.Build(successor.Name(), leader.Name()))
+
+		s.SaveAsync(t)
+	}
+}
+
+// successorOf returns the active member with the highest role of the team, the most recently seen one breaks the ties.
+// Returns nil if every member is inactive.
+func successorOf(t *team.PlayerTeam, isInactive func(u *user.User) bool) *user.User {
+	var (
+		successor *user.User
+		role      = team.Undefined
+	)
+
+	for xuid, r := range t.Members() {
+		if r == team.Leader {
+			continue
+		}
+
+		u := userService.LookupByXUID(xuid)
+		if u == nil || isInactive(u) {
+			continue
+		}
+
+		if r.HighestThan(role) || (r == role && u.LastSeen().After(successor.LastSeen())) {
+			successor, role = u, r
+		}
+	}
+
+	return successor
+}
+
+// inactive returns true if the user is offline and was last seen before the inactivity period.
+// Users without a last seen time are never considered inactive.
+func inactive(u *user.User, inactivity time.Duration) bool {
+	if _, ok := disrupt.SRV.PlayerByXUID(u.XUID()); ok {
+		return false
+	}
+
+	lastSeen := u.LastSeen()
+
+	return !lastSeen.IsZero() && time.Since(lastSeen) >= inactivity
+}
+
 func (s *TeamService) Hook() error {
 	if s.col != nil {
 		return errors.New("repository already set")
@@ -304,6 +705,19 @@ func (s *TeamService) Hook() error {
 		}
 
 		s.cache(t)
+
+		if pt, ok := t.(*team.PlayerTeam); ok {
+			for xuid := range pt.Members() {
+				s.CacheMember(xuid, pt.Tracker().Id())
+			}
+		}
+
+		// Rebuild the chunk index from the cuboids of the team
+		for key, bBoxes := range t.Tracker().Cuboids() {
+			for _, bbox := range bBoxes {
+				s.index(t.Tracker().Id(), key, bbox)
+			}
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -359,5 +773,5 @@ var teamService = &TeamService{
 	teams:         make(map[string]team.Team),
 	teamIds:       make(map[string]string),
 	members:       make(map[string]string),
-	teamsPerChunk: make(map[string]map[world.ChunkPos][]string),
+	teamsPerChunk: make(map[team.WorldKey]map[world.ChunkPos][]string),
 }
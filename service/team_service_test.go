@@ -0,0 +1,241 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// newTestTeamService returns an empty team service that is not hooked to the database.
+func newTestTeamService() *TeamService {
+	return &TeamService{
+		teams:         make(map[string]team.Team),
+		teamIds:       make(map[string]string),
+		members:       make(map[string]string),
+		teamsPerChunk: make(map[team.WorldKey]map[world.ChunkPos][]string),
+	}
+}
+
+// newTestWorld returns an empty world of the dimension that is closed when the test ends.
+func newTestWorld(t *testing.T, dim world.Dimension) *world.World {
+	w := world.Config{Dim: dim}.New()
+	t.Cleanup(func() {
+		_ = w.Close()
+	})
+
+	return w
+}
+
+// indexed returns the chunks of the world where the team is registered.
+func indexed(s *TeamService, id string, w *world.World) []world.ChunkPos {
+	s.teamsPerChunkMu.RLock()
+	defer s.teamsPerChunkMu.RUnlock()
+
+	var chunks []world.ChunkPos
+	for pos, ids := range s.teamsPerChunk[team.WorldKeyOf(w)] {
+		if slices.Contains(ids, id) {
+			chunks = append(chunks, pos)
+		}
+	}
+
+	slices.SortFunc(chunks, func(a, b world.ChunkPos) int {
+		if a[0] != b[0] {
+			return int(a[0] - b[0])
+		}
+
+		return int(a[1] - b[1])
+	})
+
+	return chunks
+}
+
+func TestChunksWithin(t *testing.T) {
+	tests := []struct {
+		name string
+		bbox cube.BBox
+		want []world.ChunkPos
+	}{
+		{"single chunk", cube.Box(0, 0, 0, 16, 256, 16), []world.ChunkPos{{0, 0}}},
+		{"across chunks", cube.Box(8, 0, 8, 24, 256, 24), []world.ChunkPos{{0, 0}, {0, 1}, {1, 0}, {1, 1}}},
+		{"negative", cube.Box(-16, 0, -1, 0, 256, 0), []world.ChunkPos{{-1, -1}}},
+	}
+
+	for _, test := range tests {
+		if got := chunksWithin(test.bbox); !slices.Equal(got, test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestClaimIndexesChunks(t *testing.T) {
+	s, w := newTestTeamService(), newTestWorld(t, world.Overworld)
+	pt := team.NewPlayerTeam("leader", "Alpha")
+
+	s.Claim(pt, w, cube.Box(0, 0, 0, 32, 256, 16))
+
+	if got := indexed(s, pt.Tracker().Id(), w); !slices.Equal(got, []world.ChunkPos{{0, 0}, {1, 0}}) {
+		t.Fatalf("expected the team in chunks [0 0] and [1 0], got %v", got)
+	}
+
+	// Claiming the same chunk again must not register the team twice
+	s.Claim(pt, w, cube.Box(0, 0, 0, 8, 256, 8))
+
+	if ids := s.teamsPerChunk[team.WorldKeyOf(w)][world.ChunkPos{0, 0}]; len(ids) != 1 {
+		t.Fatalf("expected the team once in chunk [0 0], got %v", ids)
+	}
+}
+
+func TestUnclaimKeepsSharedChunks(t *testing.T) {
+	s, w := newTestTeamService(), newTestWorld(t, world.Overworld)
+	pt := team.NewPlayerTeam("leader", "Alpha")
+	id := pt.Tracker().Id()
+
+	// Both cuboids cover the chunk [1 0]
+	first, second := cube.Box(0, 0, 0, 24, 256, 16), cube.Box(24, 0, 0, 48, 256, 16)
+	s.Claim(pt, w, first)
+	s.Claim(pt, w, second)
+
+	s.Unclaim(pt, team.WorldKeyOf(w), first)
+
+	if got := indexed(s, id, w); !slices.Equal(got, []world.ChunkPos{{1, 0}, {2, 0}}) {
+		t.Fatalf("expected the team in chunks [1 0] and [2 0], got %v", got)
+	}
+
+	s.Unclaim(pt, team.WorldKeyOf(w), second)
+
+	if got := indexed(s, id, w); len(got) != 0 {
+		t.Fatalf("expected the team in no chunks after unclaiming everything, got %v", got)
+	}
+
+	if chunks := s.teamsPerChunk[team.WorldKeyOf(w)]; len(chunks) != 0 {
+		t.Fatalf("expected the empty chunks to be removed, got %v", chunks)
+	}
+}
+
+func TestUnclaimKeepsOtherTeams(t *testing.T) {
+	s, w := newTestTeamService(), newTestWorld(t, world.Overworld)
+	alpha, beta := team.NewPlayerTeam("alpha", "Alpha"), team.NewPlayerTeam("beta", "Beta")
+
+	s.Claim(alpha, w, cube.Box(0, 0, 0, 8, 256, 16))
+	s.Claim(beta, w, cube.Box(8, 0, 0, 16, 256, 16))
+
+	s.Unclaim(alpha, team.WorldKeyOf(w), cube.Box(0, 0, 0, 8, 256, 16))
+
+	if ids := s.teamsPerChunk[team.WorldKeyOf(w)][world.ChunkPos{0, 0}]; !slices.Equal(ids, []string{beta.Tracker().Id()}) {
+		t.Fatalf("expected only the other team in chunk [0 0], got %v", ids)
+	}
+
+	// Unclaiming land the team does not own must not touch the index
+	s.Unclaim(alpha, team.WorldKeyOf(w), cube.Box(8, 0, 0, 16, 256, 16))
+
+	if ids := s.teamsPerChunk[team.WorldKeyOf(w)][world.ChunkPos{0, 0}]; len(ids) != 1 {
+		t.Fatalf("expected the other team to stay in chunk [0 0], got %v", ids)
+	}
+}
+
+func TestClaimKeepsToItsDimension(t *testing.T) {
+	s := newTestTeamService()
+	overworld, nether := newTestWorld(t, world.Overworld), newTestWorld(t, world.Nether)
+	if overworld.Name() != nether.Name() {
+		t.Fatalf("expected the worlds to share their name, got %s and %s", overworld.Name(), nether.Name())
+	}
+
+	pt := team.NewPlayerTeam("leader", "Alpha")
+	s.teams[pt.Tracker().Id()] = pt
+
+	bbox := cube.Box(0, 0, 0, 16, 128, 16)
+	s.Claim(pt, overworld, bbox)
+
+	if s.LookupAt(overworld, mgl64.Vec3{8, 64, 8}) != team.Team(pt) {
+		t.Fatal("the claim is not found in its own world")
+	}
+
+	if s.LookupAt(nether, mgl64.Vec3{8, 64, 8}) != nil {
+		t.Fatal("the claim of the overworld is also found in the nether")
+	}
+
+	if s.LookupIntersecting(nether, bbox, nil) != nil {
+		t.Fatal("the claim of the overworld blocks claiming the nether")
+	}
+}
+
+// cacheTestUser caches a user seen the given time ago, removing it when the test ends.
+func cacheTestUser(t *testing.T, xuid string, seenAgo time.Duration) *user.User {
+	u := user.New(xuid, xuid)
+	u.SetLastSeen(time.Now().Add(-seenAgo))
+
+	userService.cache(u)
+	t.Cleanup(func() {
+		userService.usersMu.Lock()
+		delete(userService.users, xuid)
+		userService.usersMu.Unlock()
+
+		userService.xuidsMu.Lock()
+		delete(userService.xuids, xuid)
+		userService.xuidsMu.Unlock()
+	})
+
+	return u
+}
+
+func TestSuccessorOf(t *testing.T) {
+	inactivity := 7 * 24 * time.Hour
+	isInactive := func(u *user.User) bool {
+		return time.Since(u.LastSeen()) >= inactivity
+	}
+
+	pt := team.NewPlayerTeam("leader", "Alpha")
+	cacheTestUser(t, "leader", 30*24*time.Hour)
+
+	pt.AddMember("member", team.Member)
+	cacheTestUser(t, "member", time.Hour)
+
+	if got := successorOf(pt, isInactive); got == nil || got.XUID() != "member" {
+		t.Fatalf("expected the only active member to succeed, got %v", got)
+	}
+
+	// The highest role wins, even over a more recently seen member
+	pt.AddMember("officer", team.Officer)
+	cacheTestUser(t, "officer", 2*time.Hour)
+
+	if got := successorOf(pt, isInactive); got == nil || got.XUID() != "officer" {
+		t.Fatalf("expected the officer to succeed, got %v", got)
+	}
+
+	// The most recently seen member breaks the ties between the same role
+	pt.AddMember("recent-officer", team.Officer)
+	cacheTestUser(t, "recent-officer", time.Minute)
+
+	if got := successorOf(pt, isInactive); got == nil || got.XUID() != "recent-officer" {
+		t.Fatalf("expected the most recently seen officer to succeed, got %v", got)
+	}
+
+	// Inactive members and members without a user are skipped
+	pt.AddMember("co-leader", team.CoLeader)
+	cacheTestUser(t, "co-leader", 8*24*time.Hour)
+	pt.AddMember("unknown", team.CoLeader)
+
+	if got := successorOf(pt, isInactive); got == nil || got.XUID() != "recent-officer" {
+		t.Fatalf("expected the inactive co-leader to be skipped, got %v", got)
+	}
+}
+
+func TestSuccessorOfEveryoneInactive(t *testing.T) {
+	pt := team.NewPlayerTeam("leader", "Alpha")
+	pt.AddMember("member", team.Member)
+	cacheTestUser(t, "member", time.Hour)
+
+	everyoneInactive := func(*user.User) bool {
+		return true
+	}
+
+	if got := successorOf(pt, everyoneInactive); got != nil {
+		t.Fatalf("expected no successor, got %s", got.XUID())
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"github.com/bitrule/disrupt"
 	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
 	"github.com/bitrule/disrupt/user"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"slices"
 	"sync"
+	"time"
 )
 
 type UserService struct {
@@ -21,6 +26,10 @@ type UserService struct {
 
 	xuidsMu sync.RWMutex
 	xuids   map[string]string
+
+	scoreboardMu   sync.Mutex      // Protects lastScoreboard and scoreboards
+	lastScoreboard time.Time       // Last time the scoreboards were updated
+	scoreboards    map[string]bool // XUID -> Whether the player is seeing a scoreboard
 }
 
 // LookupByXUID looks up a user by their XUID.
@@ -81,7 +90,7 @@ func (s *UserService) Hook() error {
 
 	s.col = disrupt.Mongo.Database(config.DBConfig().DBName).Collection("users")
 
-	cur, err := s.col.Find(context.Background(), nil)
+	cur, err := s.col.Find(context.Background(), bson.M{})
 	if err != nil {
 		return errors.Join(errors.New("failed to hook the repository: "), err)
 	}
@@ -115,7 +124,12 @@ func (s *UserService) Save(u *user.User) error {
 		return errors.New("missing repository")
 	}
 
-	r, err := s.col.UpdateOne(context.Background(), bson.M{IDKey: u.XUID()}, bson.M{"$set": u.Marshal()})
+	body, err := u.Marshal()
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal the user: "), err)
+	}
+
+	r, err := s.col.UpdateOne(context.Background(), bson.M{IDKey: u.XUID()}, bson.M{"$set": body}, options.Update().SetUpsert(true))
 	if err != nil {
 		return errors.Join(errors.New("failed to save the user: "), err)
 	}
@@ -127,9 +141,21 @@ func (s *UserService) Save(u *user.User) error {
 	return nil
 }
 
+// SaveAsync saves the user in the background and logs the error if it fails.
+func (s *UserService) SaveAsync(u *user.User) {
+	go func() {
+		if err := s.Save(u); err != nil {
+			disrupt.Log.WithError(err).Errorf("failed to save the user %s", u.Name())
+		}
+	}()
+}
+
 // Create creates a user.
 func (s *UserService) Create(xuid, name string) error {
 	u := user.New(xuid, name)
+	u.SetPvPTimer(time.Duration(config.CombatConfig().PvPTimer) * time.Second)
+	u.SetLastSeen(time.Now())
+
 	if err := s.Save(u); err != nil {
 		return err
 	}
@@ -139,6 +165,83 @@ func (s *UserService) Create(xuid, name string) error {
 	return nil
 }
 
+// DoTick runs down the PvP timer and updates the scoreboard of every online player once per second.
+// The PvP timer is paused inside safe zones, so new players do not lose it until they leave the spawn.
+// This function should be called every tick.
+func (s *UserService) DoTick() {
+	s.scoreboardMu.Lock()
+	defer s.scoreboardMu.Unlock()
+
+	elapsed := time.Since(s.lastScoreboard)
+	if elapsed < time.Second {
+		return
+	}
+
+	s.lastScoreboard = time.Now()
+
+	for _, p := range disrupt.SRV.Players() {
+		u := s.LookupByXUID(p.XUID())
+		if u == nil {
+			continue
+		}
+
+		// The elapsed time is capped because the first tick has no previous update to count from
+		if u.PvPProtected() && !teamService.SafeZoneAt(p.World(), p.Position()) && u.TickPvPTimer(min(elapsed, 2*time.Second)) {
+			p.Message(message.SuccessSelfPvPTimerExpired.Build())
+		}
+
+		lines := scoreboardLines(u)
+		if len(lines) == 0 {
+			// Only remove the scoreboard once, instead of sending it every second
+			if s.scoreboards[p.XUID()] {
+				p.RemoveScoreboard()
+
+				delete(s.scoreboards, p.XUID())
+			}
+
+			continue
+		}
+
+		sb := scoreboard.New(message.ScoreboardTitle.Build())
+		for i, line := range lines {
+			sb.Set(i, line)
+		}
+
+		p.SendScoreboard(sb)
+
+		s.scoreboards[p.XUID()] = true
+	}
+}
+
+// scoreboardLines returns the lines of the user's scoreboard, which are the timers running for them.
+func scoreboardLines(u *user.User) []string {
+	var lines []string
+	if u.CombatTagged() {
+		lines = append(lines, message.ScoreboardCombatTag.Build(u.CombatTagRemaining().Round(time.Second).String()))
+	}
+
+	if u.PvPProtected() {
+		lines = append(lines, message.ScoreboardPvPTimer.Build(u.PvPTimer().Round(time.Second).String()))
+	}
+
+	if u.Home().Active() {
+		lines = append(lines, message.ScoreboardHome.Build(u.Home().Remaining().Round(time.Second).String()))
+	}
+
+	if u.Logout().Active() {
+		lines = append(lines, message.ScoreboardLogout.Build(u.Logout().Remaining().Round(time.Second).String()))
+	}
+
+	return lines
+}
+
+// Deathban bans the user from the map after dying, the ban is set right away and saved in the background.
+func (s *UserService) Deathban(u *user.User) {
+	u.SetDeathban(time.Duration(config.DeathbanConfig().Time) * time.Second)
+
+	s.SaveAsync(u)
+}
+
 func (s *UserService) First(targets []cmd.Target) *player.Player {
 	// Why this have more than one target?
 	if len(targets) > 1 {
@@ -154,9 +257,19 @@ func (s *UserService) First(targets []cmd.Target) *player.Player {
 	return nil
 }
 
+// Operator returns true if the source is allowed to use the admin commands, which are the console
+// and the players listed in the operators configuration, because there is no permission system.
+func (s *UserService) Operator(src cmd.Source) bool {
+	p, ok := src.(*player.Player)
+
+	return !ok || slices.Contains(config.OperatorConfig().XUIDs, p.XUID())
+}
+
 var userService = &UserService{
 	users: make(map[string]*user.User),
 	xuids: make(map[string]string),
+
+	scoreboards: make(map[string]bool),
 }
 
 func User() *UserService {
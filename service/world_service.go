@@ -1,50 +1,94 @@
 package service
 
 import (
+	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"os"
+	"path/filepath"
 	"sync"
 )
 
+// WorldsDir is the directory where the worlds loaded lazily are stored.
+var WorldsDir = "worlds"
+
 type WorldService struct {
 	worldsMu sync.RWMutex
-	worlds   map[string]*world.World
+	worlds   map[team.WorldKey]*world.World
 }
 
-// LookupByName looks up a world by its name.
+// LookupByName looks up an overworld by its name. Also, see Lookup.
 func (s *WorldService) LookupByName(name string) *world.World {
+	return s.Lookup(name, world.Overworld)
+}
+
+// Lookup looks up a world by its name and dimension.
+func (s *WorldService) Lookup(name string, dim world.Dimension) *world.World {
 	s.worldsMu.RLock()
 	defer s.worldsMu.RUnlock()
 
-	if w, ok := s.worlds[name]; ok {
+	if w, ok := s.worlds[team.WorldKey{Name: name, Dim: dim}]; ok {
 		return w
 	}
 
 	return nil
 }
 
-func (s *WorldService) Load(name string) *world.World {
-	// TODO: Create world struct and load it
-	w := world.New(name)
+// Load returns the world with the given name and dimension, loading it from the worlds directory if it is not loaded yet.
+// Returns nil if the world does not exist or fails to load.
+func (s *WorldService) Load(name string, dim world.Dimension) *world.World {
+	if w := s.Lookup(name, dim); w != nil {
+		return w
+	}
+
+	dir := filepath.Join(WorldsDir, name)
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+
+	prov, err := mcdb.Config{Log: &disrupt.Log}.Open(dir)
+	if err != nil {
+		disrupt.Log.WithError(err).Errorf("failed to open the world '%s'", name)
+
+		return nil
+	}
+
+	w := world.Config{
+		Log:      &disrupt.Log,
+		Provider: prov,
+		Dim:      dim,
+		Entities: entity.DefaultRegistry,
+	}.New()
+
 	s.cache(w)
+
 	return w
 }
 
+// Register caches a world loaded by the server, like the default worlds, so it can be looked up by its name and dimension.
+func (s *WorldService) Register(w *world.World) {
+	s.cache(w)
+}
+
 // Unload unloads a world from the repository.
 func (s *WorldService) Unload(w *world.World) {
 	s.worldsMu.Lock()
-	delete(s.worlds, w.Name())
+	delete(s.worlds, team.WorldKeyOf(w))
 	s.worldsMu.Unlock()
 }
 
 // Cache caches a world in the repository.
 func (s *WorldService) cache(w *world.World) {
 	s.worldsMu.Lock()
-	s.worlds[w.Name()] = w
+	s.worlds[team.WorldKeyOf(w)] = w
 	s.worldsMu.Unlock()
 }
 
+// Hook hooks the world service. Worlds are loaded lazily when they are needed, see Load.
 func (s *WorldService) Hook() error {
-
+	return nil
 }
 
 func World() *WorldService {
@@ -52,5 +96,5 @@ func World() *WorldService {
 }
 
 var worldService = &WorldService{
-	worlds: make(map[string]*world.World),
+	worlds: make(map[team.WorldKey]*world.World),
 }
@@ -0,0 +1,32 @@
+package team
+
+import (
+	"errors"
+	"github.com/go-gl/mathgl/mgl64"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// marshalVec3 returns the Vec3 as a slice, so it is stored as an array of three numbers.
+func marshalVec3(vec mgl64.Vec3) []float64 {
+	return []float64{vec[0], vec[1], vec[2]}
+}
+
+// unmarshalVec3 decodes a Vec3 stored as an array of three numbers.
+func unmarshalVec3(v interface{}) (mgl64.Vec3, error) {
+	arr, ok := v.(primitive.A)
+	if !ok || len(arr) != 3 {
+		return mgl64.Vec3{}, errors.New("vec3 is not an array of 3 elements")
+	}
+
+	var vec mgl64.Vec3
+	for i, e := range arr {
+		f, ok := e.(float64)
+		if !ok {
+			return mgl64.Vec3{}, errors.New("vec3 element is not a float64")
+		}
+
+		vec[i] = f
+	}
+
+	return vec, nil
+}
@@ -0,0 +1,25 @@
+package team
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// roundTrip encodes the body as a BSON document and decodes it back, like the services do with the
+// documents they save and load.
+func roundTrip(t *testing.T, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	b, err := bson.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := bson.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	return decoded
+}
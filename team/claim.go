@@ -0,0 +1,72 @@
+package team
+
+import (
+	"github.com/bitrule/disrupt/config"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// wandKey is the key of the value stored in the claim wand item stack.
+var wandKey = "claim_wand"
+
+// ClaimWand returns the item used to select the two corners of a claim.
+func ClaimWand() item.Stack {
+	return item.NewStack(item.Hoe{Tier: item.ToolTierGold}, 1).
+		WithCustomName(text.Reset+text.Gold+"Claim Wand").
+		WithLore(
+			text.Yellow+"Left click a block to select the first corner.",
+			text.Yellow+"Right click a block to select the second corner.",
+			text.Yellow+"Use "+text.Gold+"'/team claim'"+text.Yellow+" to claim the selection.",
+		).
+		WithValue(wandKey, true)
+}
+
+// IsClaimWand returns true if the item stack is the claim wand.
+func IsClaimWand(s item.Stack) bool {
+	v, ok := s.Value(wandKey)
+	if !ok {
+		return false
+	}
+
+	wand, ok := v.(bool)
+	return ok && wand
+}
+
+// ClaimBox returns the cuboid between the two corners passed.
+// The cuboid always covers the whole height of the world, from the bottom to the build limit.
+func ClaimBox(w *world.World, first, second cube.Pos) cube.BBox {
+	r := w.Range()
+
+	return cube.Box(
+		float64(min(first.X(), second.X())),
+		float64(r.Min()),
+		float64(min(first.Z(), second.Z())),
+		float64(max(first.X(), second.X())+1),
+		float64(r.Max()+1),
+		float64(max(first.Z(), second.Z())+1),
+	)
+}
+
+// SelectionBox returns the cuboid between the two corners passed, including both corner blocks.
+func SelectionBox(first, second cube.Pos) cube.BBox {
+	return cube.Box(
+		float64(min(first.X(), second.X())),
+		float64(min(first.Y(), second.Y())),
+		float64(min(first.Z(), second.Z())),
+		float64(max(first.X(), second.X())+1),
+		float64(max(first.Y(), second.Y())+1),
+		float64(max(first.Z(), second.Z())+1),
+	)
+}
+
+// ClaimCost returns the balance charged to claim the cuboid, based on its surface.
+func ClaimCost(bbox cube.BBox) int32 {
+	return int32(bbox.Width()*bbox.Length()) * config.TeamConfig().Claim.PricePerBlock
+}
+
+// ClaimRefund returns the balance refunded when the cuboid is unclaimed.
+func ClaimRefund(bbox cube.BBox) int32 {
+	return ClaimCost(bbox) * config.TeamConfig().Claim.RefundPercentage / 100
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/team/tickable"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strings"
+	"time"
+)
+
+type KoTHCreateCmd struct {
+	Sub      cmd.SubCommand    `cmd:"create"`
+	Name     string            `cmd:"name"`
+	Duration cmd.Optional[int] `cmd:"minutes"`
+}
+
+func (c KoTHCreateCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if strings.TrimSpace(c.Name) == "" {
+		output.Error(team.Prefix + "Name cannot be empty.")
+	} else if service.Team().LookupByName(c.Name) != nil {
+		output.Error(message.ErrTeamAlreadyExists.Build(c.Name))
+	} else if minutes := c.Duration.LoadOr(10); minutes <= 0 {
+		output.Error(message.ErrKoTHInvalidDuration.Build())
+	} else {
+		st := team.NewSystemTeam(c.Name)
+		st.SetTick(tickable.NewKoTHTick(st.Tracker().Id(), time.Duration(minutes)*time.Minute, cube.BBox{}))
+
+		go service.Team().Create(p, st)
+	}
+}
+
+// Allow only lets the operators create KoTHs.
+func (KoTHCreateCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type KoTHSetZoneCmd struct {
+	Sub  cmd.SubCommand `cmd:"setzone"`
+	Name string         `cmd:"name"`
+}
+
+func (c KoTHSetZoneCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if st, _, ok := lookupKoTH(c.Name); !ok {
+		output.Error(message.ErrKoTHNotFound.Build(c.Name))
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if w, first, second, ok := u.Selection().Corners(); !ok {
+		if _, err := p.Inventory().AddItem(team.ClaimWand()); err != nil {
+			output.Error(text.Red + "Your inventory is full.")
+		} else {
+			output.Print(message.SuccessSelfClaimWandReceived.Build())
+		}
+	} else if w != p.World() {
+		output.Error(message.ErrClaimOtherWorld.Build())
+	} else if other := service.Team().LookupIntersecting(p.World(), team.ClaimBox(p.World(), first, second), func(t team.Team) bool {
+		// The land of the KoTH itself is replaced by the new zone
+		return t == team.Team(st)
+	}); other != nil {
+		output.Error(message.ErrClaimOverlaps.Build(other.Tracker().Name()))
+	} else if !service.KoTH().SetZone(st, p.World(), team.SelectionBox(first, second)) {
+		output.Error(message.ErrKoTHZoneWorld.Build(st.Tracker().Name()))
+	} else {
+		u.Selection().Reset()
+
+		output.Print(message.SuccessKoTHZoneSet.Build(st.Tracker().Name()))
+
+		go saveTeam(p, st)
+	}
+}
+
+// Allow only lets the operators set the capture zone, because it claims land for free.
+func (KoTHSetZoneCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type KoTHSetDurationCmd struct {
+	Sub     cmd.SubCommand `cmd:"setduration"`
+	Name    string         `cmd:"name"`
+	Minutes int            `cmd:"minutes"`
+}
+
+func (c KoTHSetDurationCmd) Run(_ cmd.Source, output *cmd.Output) {
+	if st, kt, ok := lookupKoTH(c.Name); !ok {
+		output.Error(message.ErrKoTHNotFound.Build(c.Name))
+	} else if c.Minutes <= 0 {
+		output.Error(message.ErrKoTHInvalidDuration.Build())
+	} else {
+		kt.SetDuration(time.Duration(c.Minutes) * time.Minute)
+
+		output.Print(message.SuccessKoTHDurationSet.Build(st.Tracker().Name(), kt.Duration().String()))
+
+		go func() {
+			if err := service.Team().Save(st); err != nil {
+				disrupt.Log.WithError(err).Errorf("failed to save the KoTH '%s'", st.Tracker().Name())
+			}
+		}()
+	}
+}
+
+// Allow only lets the operators change the duration of KoTHs.
+func (KoTHSetDurationCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type KoTHStartCmd struct {
+	Sub  cmd.SubCommand `cmd:"start"`
+	Name string         `cmd:"name"`
+}
+
+func (c KoTHStartCmd) Run(_ cmd.Source, output *cmd.Output) {
+	if st, kt, ok := lookupKoTH(c.Name); !ok {
+		output.Error(message.ErrKoTHNotFound.Build(c.Name))
+	} else if _, ok := service.KoTH().ZoneWorld(st); !ok {
+		output.Error(message.ErrKoTHNoZone.Build(c.Name))
+	} else if !kt.Start() {
+		output.Error(message.ErrKoTHAlreadyActive.Build(c.Name))
+	}
+}
+
+// Allow only lets the operators start KoTHs.
+func (KoTHStartCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type KoTHStopCmd struct {
+	Sub  cmd.SubCommand `cmd:"stop"`
+	Name string         `cmd:"name"`
+}
+
+func (c KoTHStopCmd) Run(_ cmd.Source, output *cmd.Output) {
+	if _, kt, ok := lookupKoTH(c.Name); !ok {
+		output.Error(message.ErrKoTHNotFound.Build(c.Name))
+	} else if !kt.Stop() {
+		output.Error(message.ErrKoTHNotActive.Build(c.Name))
+	}
+}
+
+// Allow only lets the operators stop KoTHs.
+func (KoTHStopCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type KoTHListCmd struct {
+	Sub cmd.SubCommand `cmd:"list"`
+}
+
+func (KoTHListCmd) Run(_ cmd.Source, output *cmd.Output) {
+	var running int
+
+	for _, st := range service.Team().SystemTeams() {
+		kt, ok := st.KoTH()
+		if !ok || !kt.Active() {
+			continue
+		}
+
+		capper := "None"
+		if u := service.User().LookupByXUID(kt.Capper()); u != nil {
+			capper = u.Name()
+		}
+
+		output.Print(message.SuccessKoTHListEntry.Build(st.Tracker().Name(), kt.Remaining().Round(time.Second).String(), capper))
+
+		running++
+	}
+
+	if running == 0 {
+		output.Error(message.ErrKoTHNoneActive.Build())
+	}
+}
+
+// lookupKoTH looks up a system team backed by a KoTH tick by its name.
+func lookupKoTH(name string) (*team.SystemTeam, *tickable.KoTHTick, bool) {
+	st, ok := service.Team().LookupByName(name).(*team.SystemTeam)
+	if !ok {
+		return nil, nil, false
+	}
+
+	kt, ok := st.KoTH()
+
+	return st, kt, ok
+}
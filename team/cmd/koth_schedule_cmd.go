@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"time"
+)
+
+type KoTHScheduleCmd struct {
+	Sub cmd.SubCommand `cmd:"schedule"`
+}
+
+func (KoTHScheduleCmd) Run(_ cmd.Source, output *cmd.Output) {
+	upcoming := service.KoTH().Upcoming()
+	if len(upcoming) == 0 {
+		output.Error(message.ErrKoTHNoneScheduled.Build())
+
+		return
+	}
+
+	output.Print(message.SuccessKoTHScheduleHeader.Build())
+
+	for _, u := range upcoming {
+		output.Print(message.SuccessKoTHScheduleEntry.Build(u.Name, u.At.Format("Mon 15:04"), time.Until(u.At).Round(time.Second).String()))
+	}
+}
+
+type KoTHNextCmd struct {
+	Sub cmd.SubCommand `cmd:"next"`
+}
+
+func (KoTHNextCmd) Run(_ cmd.Source, output *cmd.Output) {
+	if u, ok := service.KoTH().Next(); !ok {
+		output.Error(message.ErrKoTHNoneScheduled.Build())
+	} else {
+		output.Print(message.SuccessKoTHNext.Build(u.Name, u.At.Format("Mon 15:04"), time.Until(u.At).Round(time.Second).String()))
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strconv"
+)
+
+type TeamClaimCmd struct {
+	Sub cmd.SubCommand `cmd:"claim"`
+}
+
+func (TeamClaimCmd) Run(src cmd.Source, output *cmd.Output) {
+	claimConfig := config.TeamConfig().Claim
+
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if !t.Can(s.XUID(), team.ClaimPermission) {
+		output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.ClaimPermission).Name()))
+	} else if u := service.User().LookupByXUID(s.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if w, first, second, ok := u.Selection().Corners(); !ok {
+		// Without a complete selection, the command gives the wand to start selecting
+		if _, err := s.Inventory().AddItem(team.ClaimWand()); err != nil {
+			output.Error(text.Red + "Your inventory is full.")
+		} else {
+			output.Print(message.SuccessSelfClaimWandReceived.Build())
+		}
+	} else if w != s.World() {
+		output.Error(message.ErrClaimOtherWorld.Build())
+	} else if bbox := team.ClaimBox(s.World(), first, second); int(bbox.Width()) < claimConfig.MinSize || int(bbox.Length()) < claimConfig.MinSize {
+		output.Error(message.ErrClaimTooSmall.Build(strconv.Itoa(claimConfig.MinSize)))
+	} else if int(bbox.Width()) > claimConfig.MaxSize || int(bbox.Length()) > claimConfig.MaxSize {
+		output.Error(message.ErrClaimTooLarge.Build(strconv.Itoa(claimConfig.MaxSize)))
+	} else if other := service.Team().LookupIntersecting(s.World(), bbox, nil); other != nil {
+		output.Error(message.ErrClaimOverlaps.Build(other.Tracker().Name()))
+	} else if cost := team.ClaimCost(bbox); !t.Tracker().Withdraw(cost) {
+		output.Error(message.ErrClaimNotEnoughBalance.Build(strconv.Itoa(int(cost)), strconv.Itoa(int(t.Tracker().Balance()))))
+	} else {
+		service.Team().Claim(t, s.World(), bbox)
+
+		u.Selection().Reset()
+
+		t.Broadcast(message.SuccessTeamLandClaimed.Build(s.Name(), strconv.Itoa(int(cost))))
+
+		go saveClaim(s, t, team.WorldKeyOf(w), bbox, cost)
+	}
+}
+
+// saveClaim saves the team after claiming the land. If it fails, the land is unclaimed and the cost
+// is refunded, so the team never pays for a claim that was not stored.
+func saveClaim(p *player.Player, t *team.PlayerTeam, key team.WorldKey, bbox cube.BBox, cost int32) {
+	if err := service.Team().Save(t); err != nil {
+		service.Team().Unclaim(t, key, bbox)
+		t.Tracker().AddBalance(cost)
+
+		p.Message(text.DarkRed + "Failed to save the claim, your team was refunded: " + text.Red + err.Error())
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+)
+
+type TeamFriendlyFireCmd struct {
+	Sub cmd.SubCommand `cmd:"ff"`
+}
+
+func (TeamFriendlyFireCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if !t.Can(s.XUID(), team.FriendlyFirePermission) {
+		output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.FriendlyFirePermission).Name()))
+	} else {
+		enabled, _ := t.Tracker().Option(team.FriendlyFireKeyOption).(bool)
+		t.Tracker().SetOption(team.FriendlyFireKeyOption, !enabled)
+
+		if enabled {
+			t.Broadcast(message.SuccessTeamFriendlyFireDisabled.Build(s.Name()))
+		} else {
+			t.Broadcast(message.SuccessTeamFriendlyFireEnabled.Build(s.Name()))
+		}
+
+		go saveTeam(s, t)
+	}
+}
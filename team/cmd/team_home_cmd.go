@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"time"
+)
+
+type TeamHomeCmd struct {
+	Sub cmd.SubCommand `cmd:"home"`
+}
+
+func (TeamHomeCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if u := service.User().LookupByXUID(s.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if hq, ok := service.Team().ResolveHQ(t); !ok {
+		output.Error(message.ErrTeamNoHQ.Build())
+	} else if s.World().Dimension() == world.End {
+		output.Error(message.ErrTeamHomeEnd.Build())
+	} else if u.CombatTagged() {
+		output.Error(message.ErrTeamHomeCombat.Build(u.CombatTagRemaining().Round(time.Second).String()))
+	} else if warmup := homeWarmup(s, t); warmup == 0 {
+		teleportHome(s, hq)
+	} else if !u.Home().Start(warmup, func() {
+		// The HQ might have changed during the warmup
+		if hq, ok := service.Team().ResolveHQ(t); ok {
+			teleportHome(s, hq)
+		} else {
+			s.Message(message.ErrTeamNoHQ.Build())
+		}
+	}) {
+		output.Error(message.ErrTeamHomeAlreadyWarming.Build())
+	} else {
+		output.Print(message.SuccessSelfTeamHomeWarmup.Build(warmup.String()))
+	}
+}
+
+// homeWarmup returns the time the player must wait before teleporting home, based on the land they stand in.
+// It is instant inside safe zones and longer inside the land of an enemy team.
+func homeWarmup(p *player.Player, t *team.PlayerTeam) time.Duration {
+	homeConfig := config.TeamConfig().Home
+
+	if service.Team().SafeZoneAt(p.World(), p.Position()) {
+		return 0
+	}
+
+	if other, ok := service.Team().LookupAt(p.World(), p.Position()).(*team.PlayerTeam); ok && other != t && !t.IsAlly(other.Tracker().Id()) {
+		return time.Duration(homeConfig.EnemyWarmup) * time.Second
+	}
+
+	return time.Duration(homeConfig.Warmup) * time.Second
+}
+
+// teleportHome teleports the player to the HQ, moving them to its world if they are in another one.
+// The HQ must be resolved, see TeamService.ResolveHQ.
+func teleportHome(p *player.Player, hq team.HQ) {
+	if p.World() != hq.World() {
+		hq.World().AddEntity(p)
+	}
+
+	p.Teleport(hq.Position())
+
+	rot := p.Rotation()
+	p.Move(mgl64.Vec3{}, hq.Rotation().Yaw()-rot.Yaw(), hq.Rotation().Pitch()-rot.Pitch())
+
+	p.Message(message.SuccessSelfTeamHomeTeleport.Build())
+}
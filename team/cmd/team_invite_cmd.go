@@ -22,8 +22,8 @@ func (c TeamInviteCmd) Run(src cmd.Source, output *cmd.Output) {
 		output.Error(message.ErrSelfNotInTeam.Build())
 	} else if r := t.Member(s.XUID()); r == team.Undefined {
 		output.Error(message.ErrSelfNotInTeam.Build())
-	} else if r.LowestThan(team.Officer) { // Check if the player is an officer or higher, if not, return an error
-		output.Error(message.ErrSelfNotOfficer.Build())
+	} else if !t.Can(s.XUID(), team.InvitePermission) {
+		output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.InvitePermission).Name()))
 	} else if t.Member(p.XUID()) != team.Undefined {
 		output.Error(message.ErrPlayerAlreadyMember.Build(p.Name()))
 	} else if service.Team().LookupByMember(p.XUID()) != nil {
@@ -21,13 +21,13 @@ func (c TeamKickCmd) Run(src cmd.Source, output *cmd.Output) {
         output.Error(message.ErrSelfNotInTeam.Build())
     } else if r := t.Member(s.XUID()); r == team.Undefined {
         output.Error(message.ErrSelfNotInTeam.Build())
-    } else if r.LowestThan(team.Officer) { // Check if the player is an officer or higher, if not, return an error
-        output.Error(message.ErrSelfNotOfficer.Build())
+    } else if !t.Can(s.XUID(), team.KickPermission) {
+        output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.KickPermission).Name()))
     } else if t.Member(p.XUID()) == team.Undefined {
         output.Error(message.ErrPlayerNotTeamMember.Build(p.Name()))
     } else if p.XUID() == s.XUID() {
         output.Error(message.ErrCannotUseOnSelf.Build())
-    } else if r := t.Member(p.XUID()); r == team.Leader {
+    } else if !r.HighestThan(t.Member(p.XUID())) {
         output.Error(message.ErrPlayerHighestRole.Build())
     } else {
         output.Print(message.SuccessSelfTeamMemberKicked.Build(p.Name()))
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+)
+
+type TeamLeaderCmd struct {
+	Sub     cmd.SubCommand `cmd:"leader"`
+	Targets []cmd.Target   `cmd:"target"`
+}
+
+func (c TeamLeaderCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if p := service.User().First(c.Targets); p == nil {
+		output.Error("No targets specified.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if t.Member(s.XUID()) != team.Leader {
+		output.Error(message.ErrSelfNotLeader.Build())
+	} else if p.XUID() == s.XUID() {
+		output.Error(message.ErrCannotUseOnSelf.Build())
+	} else if !t.TransferOwnership(p.XUID(), team.CoLeader) {
+		output.Error(message.ErrPlayerNotTeamMember.Build(p.Name()))
+	} else {
+		t.Broadcast(message.SuccessTeamLeaderChanged.Build(p.Name(), s.Name()))
+
+		go saveTeam(s, t)
+	}
+}
@@ -19,7 +19,7 @@ func (TeamLeaveCmd) Run(src cmd.Source, output *cmd.Output) {
     } else if r := t.Member(s.XUID()); r == team.Undefined {
         output.Error(message.ErrSelfNotInTeam.Build())
     } else if r == team.Leader {
-        output.Error("You cannot use this command as the team leader. Use " + text.DarkRed + "'/team disband'" + text.Red + " instead.")
+        output.Error("You cannot use this command as the team leader. Use " + text.DarkRed + "'/team leader <player>'" + text.Red + " to pass the leadership or " + text.DarkRed + "'/team disband'" + text.Red + " instead.")
     } else {
         t.Broadcast(message.SuccessTeamMemberLeft.Build(s.Name()))
 
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+)
+
+type TeamPermsCmd struct {
+	Sub cmd.SubCommand `cmd:"perms"`
+}
+
+func (TeamPermsCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else {
+		output.Print(message.TeamPermissionsHeader.Build(t.Tracker().Name()))
+
+		for _, action := range team.Permissions() {
+			output.Print(message.TeamPermissionsEntry.Build(action, t.Permission(action).Name()))
+		}
+	}
+}
+
+type TeamPermsSetCmd struct {
+	Sub    cmd.SubCommand   `cmd:"perms"`
+	Action permissionAction `cmd:"action"`
+	Role   permissionRole   `cmd:"role"`
+}
+
+func (c TeamPermsSetCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if t.Member(s.XUID()) != team.Leader {
+		output.Error(message.ErrSelfNotLeader.Build())
+	} else if action := string(c.Action); !team.ValidPermission(action) {
+		output.Error(message.ErrInvalidPermission.Build(action))
+	} else {
+		role := team.RoleFromName(string(c.Role))
+		t.SetPermission(action, role)
+
+		t.Broadcast(message.SuccessTeamPermissionUpdated.Build(s.Name(), action, role.Name()))
+
+		go saveTeam(s, t)
+	}
+}
+
+// permissionAction is an enum of the actions of the permission matrix
+type permissionAction string
+
+func (permissionAction) Type() string {
+	return "action"
+}
+
+func (permissionAction) Options(cmd.Source) []string {
+	return team.Permissions()
+}
+
+// permissionRole is an enum of the roles that can be allowed to do an action
+type permissionRole string
+
+func (permissionRole) Type() string {
+	return "role"
+}
+
+func (permissionRole) Options(cmd.Source) []string {
+	return []string{team.Leader.Name(), team.CoLeader.Name(), team.Officer.Name(), team.Member.Name()}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+)
+
+type TeamPromoteCmd struct {
+	Sub     cmd.SubCommand `cmd:"promote"`
+	Targets []cmd.Target   `cmd:"target"`
+}
+
+func (c TeamPromoteCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if p := service.User().First(c.Targets); p == nil {
+		output.Error("No targets specified.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if p.XUID() == s.XUID() {
+		output.Error(message.ErrCannotUseOnSelf.Build())
+	} else if r := t.Member(p.XUID()); r == team.Undefined {
+		output.Error(message.ErrPlayerNotTeamMember.Build(p.Name()))
+	} else if role, ok := r.Promoted(); !ok {
+		output.Error(message.ErrPlayerCannotPromote.Build(p.Name()))
+	} else if !t.Member(s.XUID()).HighestThan(role) {
+		// Nobody can promote a member to their own role or above
+		output.Error(message.ErrSelfRoleTooLow.Build(p.Name()))
+	} else {
+		t.SetRole(p.XUID(), role)
+
+		t.Broadcast(message.SuccessTeamMemberPromoted.Build(p.Name(), role.Name(), s.Name()))
+
+		go saveTeam(s, t)
+	}
+}
+
+type TeamDemoteCmd struct {
+	Sub     cmd.SubCommand `cmd:"demote"`
+	Targets []cmd.Target   `cmd:"target"`
+}
+
+func (c TeamDemoteCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if p := service.User().First(c.Targets); p == nil {
+		output.Error("No targets specified.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if p.XUID() == s.XUID() {
+		output.Error(message.ErrCannotUseOnSelf.Build())
+	} else if r := t.Member(p.XUID()); r == team.Undefined {
+		output.Error(message.ErrPlayerNotTeamMember.Build(p.Name()))
+	} else if !t.Member(s.XUID()).HighestThan(r) {
+		// Nobody can demote a member with their own role or above
+		output.Error(message.ErrSelfRoleTooLow.Build(p.Name()))
+	} else if role, ok := r.Demoted(); !ok {
+		output.Error(message.ErrPlayerCannotDemote.Build(p.Name()))
+	} else {
+		t.SetRole(p.XUID(), role)
+
+		t.Broadcast(message.SuccessTeamMemberDemoted.Build(p.Name(), role.Name(), s.Name()))
+
+		go saveTeam(s, t)
+	}
+}
@@ -6,24 +6,34 @@ import (
     "github.com/bitrule/disrupt/team"
     "github.com/df-mc/dragonfly/server/cmd"
     "github.com/df-mc/dragonfly/server/player"
+    "strconv"
 )
 
-type TeamSetHomeCmd struct{}
+type TeamSetHomeCmd struct {
+    Sub cmd.SubCommand `cmd:"sethome"`
+}
 
 func (TeamSetHomeCmd) Run(src cmd.Source, output *cmd.Output) {
     if s, ok := src.(*player.Player); !ok {
         output.Error("This command can only be run by a player.")
     } else if t := service.Team().LookupByMember(s.XUID()); t == nil {
         output.Error(message.ErrSelfNotInTeam.Build())
-    } else if r := t.Member(s.XUID()); r.LowestThan(team.Leader) {
-        output.Error(message.ErrSelfNotLeader.Build())
+    } else if !t.Can(s.XUID(), team.SetHomePermission) {
+        output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.SetHomePermission).Name()))
     } else if !t.Tracker().Inside(s.World(), s.Position()) {
         output.Error("You must be inside the team's territory to set the home.")
     } else {
         pos := s.Position()
 
-        t.Broadcast(message.SuccessTeamHQUpdated.Build(s.Name(), pos.X(), pos.Y(), pos.Z()))
-
         t.SetHQ(team.NewHQ(s.World(), pos, s.Rotation()))
+
+        t.Broadcast(message.SuccessTeamHQUpdated.Build(
+            s.Name(),
+            strconv.Itoa(int(pos.X())),
+            strconv.Itoa(int(pos.Y())),
+            strconv.Itoa(int(pos.Z())),
+        ))
+
+        go saveTeam(s, t)
     }
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/bitrule/disrupt/service"
 	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/team/tickable"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/sandertv/gophertunnel/minecraft/text"
@@ -10,17 +11,47 @@ import (
 )
 
 type TeamSystemCreateCmd struct {
-	Name string `cmd:"name"`
+	Sub  cmd.SubCommand       `cmd:"system"`
+	Name string               `cmd:"name"`
+	Tick cmd.Optional[string] `cmd:"tick"`
 }
 
 func (c TeamSystemCreateCmd) Run(src cmd.Source, output *cmd.Output) {
+	tickType, hasTick := c.Tick.Load()
+	tickType = strings.ToLower(tickType)
+
 	if p, ok := src.(*player.Player); !ok {
 		output.Error(text.Red + "This command can only be run by a player.")
 	} else if strings.TrimSpace(c.Name) == "" {
 		output.Error(team.Prefix + "Name cannot be empty.")
 	} else if service.Team().LookupByName(c.Name) != nil {
 		output.Error(text.Red + "Team with the name " + text.DarkRed + "'" + c.Name + "'" + text.Red + " already exists.")
+	} else if hasTick && tickType == tickable.KoTHType {
+		// KoTH ticks need a duration and a capture zone, so they are created with /koth create
+		output.Error(text.Red + "Use " + text.DarkRed + "/koth create" + text.Red + " to create a KoTH.")
 	} else {
-		go service.Team().Create(p, team.NewPlayerTeam(p.XUID(), c.Name))
+		st := team.NewSystemTeam(c.Name)
+		if hasTick {
+			tick, ok := tickable.New(tickType)
+			if !ok {
+				output.Error(text.Red + "Unknown tick type " + text.DarkRed + "'" + tickType + "'" + text.Red + ".")
+
+				return
+			}
+
+			st.SetTick(tick)
+
+			// Nobody can fight at spawn
+			if tickType == tickable.SpawnType {
+				st.Tracker().SetOption(team.SafeZoneKeyOption, true)
+			}
+		}
+
+		go service.Team().Create(p, st)
 	}
 }
+
+// Allow only lets the operators create system teams.
+func (TeamSystemCreateCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
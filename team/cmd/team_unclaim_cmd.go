@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strconv"
+)
+
+type TeamUnclaimCmd struct {
+	Sub cmd.SubCommand `cmd:"unclaim"`
+}
+
+func (TeamUnclaimCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if !t.Can(s.XUID(), team.UnclaimPermission) {
+		output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.UnclaimPermission).Name()))
+	} else if bbox, ok := cuboidAt(t, s); !ok {
+		output.Error(message.ErrUnclaimNotInLand.Build())
+	} else if !hqRemainsInside(t, team.WorldKeyOf(s.World()), bbox) {
+		output.Error(message.ErrUnclaimHQOutsideLand.Build())
+	} else {
+		refund := team.ClaimRefund(bbox)
+
+		service.Team().Unclaim(t, team.WorldKeyOf(s.World()), bbox)
+		t.Tracker().AddBalance(refund)
+
+		t.Broadcast(message.SuccessTeamLandUnclaimed.Build(s.Name(), strconv.Itoa(int(refund))))
+
+		go saveTeam(s, t)
+	}
+}
+
+type TeamUnclaimAllCmd struct {
+	Sub cmd.SubCommand `cmd:"unclaim"`
+	All cmd.SubCommand `cmd:"all"`
+}
+
+func (TeamUnclaimAllCmd) Run(src cmd.Source, output *cmd.Output) {
+	if s, ok := src.(*player.Player); !ok {
+		output.Error("This command can only be run by a player.")
+	} else if t := service.Team().LookupByMember(s.XUID()); t == nil {
+		output.Error(message.ErrSelfNotInTeam.Build())
+	} else if !t.Can(s.XUID(), team.UnclaimPermission) {
+		output.Error(message.ErrSelfNoPermission.Build(t.Permission(team.UnclaimPermission).Name()))
+	} else if cuboids := t.Tracker().Cuboids(); len(cuboids) == 0 {
+		output.Error(message.ErrUnclaimNoLand.Build())
+	} else if t.HQ().Valid() {
+		// Without any land left, the HQ always ends up outside
+		output.Error(message.ErrUnclaimHQOutsideLand.Build())
+	} else {
+		var refund int32
+
+		for key, bBoxes := range cuboids {
+			// Copy the slice because Unclaim modifies the tracker cuboids
+			for _, bbox := range append([]cube.BBox(nil), bBoxes...) {
+				refund += team.ClaimRefund(bbox)
+
+				service.Team().Unclaim(t, key, bbox)
+			}
+		}
+
+		t.Tracker().AddBalance(refund)
+
+		t.Broadcast(message.SuccessTeamAllLandUnclaimed.Build(s.Name(), strconv.Itoa(int(refund))))
+
+		go saveTeam(s, t)
+	}
+}
+
+// cuboidAt returns the cuboid of the team where the player is standing.
+func cuboidAt(t team.Team, p *player.Player) (cube.BBox, bool) {
+	for _, bbox := range t.Tracker().Cuboids()[team.WorldKeyOf(p.World())] {
+		if bbox.Vec3Within(p.Position()) {
+			return bbox, true
+		}
+	}
+
+	return cube.BBox{}, false
+}
+
+// hqRemainsInside returns true if the team HQ is still inside the team's land after removing the cuboid.
+func hqRemainsInside(t *team.PlayerTeam, key team.WorldKey, bbox cube.BBox) bool {
+	hq := t.HQ()
+	if !hq.Valid() || hq.WorldName() != key.Name || hq.Dimension() != key.Dim || !bbox.Vec3Within(hq.Position()) {
+		return true
+	}
+
+	for _, other := range t.Tracker().Cuboids()[key] {
+		if other != bbox && other.Vec3Within(hq.Position()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// saveTeam saves the team and notifies the player if it fails.
+// Use this function into a goroutine to prevent blocking the main thread.
+func saveTeam(p *player.Player, t team.Team) {
+	if err := service.Team().Save(t); err != nil {
+		p.Message(text.DarkRed + "Failed to save the team: " + text.Red + err.Error())
+	}
+}
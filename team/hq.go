@@ -2,31 +2,46 @@ package team
 
 import (
     "errors"
-    "github.com/bitrule/disrupt/service"
+    "fmt"
     "github.com/df-mc/dragonfly/server/block/cube"
     "github.com/df-mc/dragonfly/server/world"
     "github.com/go-gl/mathgl/mgl64"
+    "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type HQ struct {
-    w *world.World
+    wName string          // Name of the world of the HQ
+    dim   world.Dimension // Dimension of the world of the HQ, the default worlds share their name
+    w     *world.World    // World of the HQ, resolved lazily from its name and dimension
 
     pos mgl64.Vec3
     rot cube.Rotation
 
-    loaded bool
+    loaded  bool
+    invalid bool // Whether the world of the HQ no longer exists
 }
 
 // NewHQ returns a new HQ with the given world, position, and rotation.
 func NewHQ(w *world.World, pos mgl64.Vec3, rot cube.Rotation) HQ {
-    return HQ{w, pos, rot, true}
+    return HQ{wName: w.Name(), dim: w.Dimension(), w: w, pos: pos, rot: rot, loaded: true}
 }
 
-// World returns the world of the HQ.
+// World returns the world of the HQ, nil if it was not resolved yet or no longer exists.
+// Also, see Resolved and WithWorld.
 func (h HQ) World() *world.World {
     return h.w
 }
 
+// WorldName returns the name of the world of the HQ.
+func (h HQ) WorldName() string {
+    return h.wName
+}
+
+// Dimension returns the dimension of the world of the HQ.
+func (h HQ) Dimension() world.Dimension {
+    return h.dim
+}
+
 // Position returns the position of the HQ.
 func (h HQ) Position() mgl64.Vec3 {
     return h.pos
@@ -37,66 +52,87 @@ func (h HQ) Rotation() cube.Rotation {
     return h.rot
 }
 
+// Loaded returns true if the HQ was set.
+func (h HQ) Loaded() bool {
+    return h.loaded
+}
+
+// Resolved returns true if the world of the HQ was already looked up, either found or not.
+func (h HQ) Resolved() bool {
+    return h.w != nil || h.invalid
+}
+
+// Valid returns true if the HQ was set and its world still exists.
+func (h HQ) Valid() bool {
+    return h.loaded && !h.invalid
+}
+
+// WithWorld returns the HQ with its world resolved. If the world is nil, the HQ is marked invalid
+// because its world no longer exists.
+func (h HQ) WithWorld(w *world.World) HQ {
+    h.w = w
+    h.invalid = w == nil
+
+    return h
+}
+
 // Marshal marshals the HQ to a map.
 func (h HQ) Marshal() map[string]interface{} {
+    dim, _ := world.DimensionID(h.dim)
+
     return map[string]interface{}{
-        "world": h.w.Name(),
-        "pos":   h.pos,
-        "rot":   h.rot,
+        "world":     h.wName,
+        "dimension": dim,
+        "pos":       marshalVec3(h.pos),
+        "rot":       []float64{h.rot.Yaw(), h.rot.Pitch()},
     }
 }
 
 // Unmarshal unmarshals the HQ from the given map.
-func (h HQ) Unmarshal(body map[string]interface{}) error {
+// The world is only stored by its name and dimension, it is resolved later because worlds are loaded lazily.
+func (h *HQ) Unmarshal(body map[string]interface{}) error {
     wName, ok := body["world"].(string)
     if !ok {
         return errors.New("world is not a string")
     }
 
-    w := service.World().Load(wName)
-    if w == nil {
-        return errors.New("world not found")
+    pos, err := unmarshalVec3(body["pos"])
+    if err != nil {
+        return fmt.Errorf("invalid pos: %w", err)
     }
-    h.w = w
 
-    pos, ok := body["pos"].(map[string]interface{})
-    if !ok {
-        return errors.New("pos is not a map")
+    rot, ok := body["rot"].(primitive.A)
+    if !ok || len(rot) != 2 {
+        return errors.New("rot is not an array of 2 elements")
     }
 
-    x, ok := pos["x"].(float64)
+    yaw, ok := rot[0].(float64)
     if !ok {
-        return errors.New("pos.x is not a float64")
+        return errors.New("rot yaw is not a float64")
     }
 
-    y, ok := pos["y"].(float64)
+    pitch, ok := rot[1].(float64)
     if !ok {
-        return errors.New("pos.y is not a float64")
+        return errors.New("rot pitch is not a float64")
     }
 
-    z, ok := pos["z"].(float64)
-    if !ok {
-        return errors.New("pos.z is not a float64")
-    }
-
-    h.pos = mgl64.Vec3{x, y, z}
+    // HQs saved before the dimension was stored are in the overworld
+    h.dim = world.Overworld
+    if id, ok := body["dimension"].(int32); ok {
+        dim, ok := world.DimensionByID(int(id))
+        if !ok {
+            return fmt.Errorf("unknown dimension %d", id)
+        }
 
-    rot, ok := body["rot"].(map[string]float64)
-    if !ok {
-        return errors.New("rot is not a float64")
-    }
-
-    yaw, ok := rot["yaw"]
-    if !ok {
-        return errors.New("rot.yaw is not a float64")
-    }
-
-    pitch, ok := rot["pitch"]
-    if !ok {
-        return errors.New("rot.pitch is not a float64")
+        h.dim = dim
     }
 
+    h.wName = wName
+    h.w = nil
+    h.pos = pos
     h.rot = cube.Rotation{yaw, pitch}
+    h.loaded = true
+    h.invalid = false
 
     return nil
 }
@@ -0,0 +1,80 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestHQRoundTrip(t *testing.T) {
+	for _, dim := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+		hq := HQ{wName: "World", dim: dim, pos: mgl64.Vec3{10.5, 64, -20.5}, rot: cube.Rotation{90, -15}, loaded: true}
+
+		var got HQ
+		if err := got.Unmarshal(roundTrip(t, hq.Marshal())); err != nil {
+			t.Fatalf("failed to unmarshal the HQ in %v: %v", dim, err)
+		}
+
+		if got != hq {
+			t.Errorf("expected %+v, got %+v", hq, got)
+		}
+
+		if got.Resolved() || !got.Valid() {
+			t.Errorf("a loaded HQ must be valid and resolved lazily")
+		}
+	}
+}
+
+func TestHQUnmarshalWithoutDimension(t *testing.T) {
+	body := roundTrip(t, HQ{wName: "World", dim: world.Nether, loaded: true}.Marshal())
+	delete(body, "dimension")
+
+	var hq HQ
+	if err := hq.Unmarshal(body); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if hq.Dimension() != world.Overworld {
+		t.Fatalf("expected the overworld for HQs saved without a dimension, got %v", hq.Dimension())
+	}
+}
+
+func TestHQUnmarshalInvalid(t *testing.T) {
+	valid := HQ{wName: "World", dim: world.Overworld, loaded: true}.Marshal()
+
+	tests := map[string]func(body map[string]interface{}){
+		"missing world":     func(body map[string]interface{}) { delete(body, "world") },
+		"missing pos":       func(body map[string]interface{}) { delete(body, "pos") },
+		"short pos":         func(body map[string]interface{}) { body["pos"] = []float64{1, 2} },
+		"missing rot":       func(body map[string]interface{}) { delete(body, "rot") },
+		"unknown dimension": func(body map[string]interface{}) { body["dimension"] = int32(7) },
+	}
+
+	for name, corrupt := range tests {
+		body := roundTrip(t, valid)
+		corrupt(body)
+
+		var hq HQ
+		if err := hq.Unmarshal(roundTrip(t, body)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCompareAndSetHQ(t *testing.T) {
+	pt := &PlayerTeam{}
+	old := HQ{wName: "World", dim: world.Overworld, loaded: true}
+	pt.SetHQ(old)
+
+	resolved := old.WithWorld(nil)
+	if !pt.CompareAndSetHQ(old, resolved) || pt.HQ() != resolved {
+		t.Fatal("did not set the HQ while it was unchanged")
+	}
+
+	// The HQ was already replaced, so the stale resolution must be discarded
+	if pt.CompareAndSetHQ(old, old) || pt.HQ() != resolved {
+		t.Fatal("set the HQ although it changed meanwhile")
+	}
+}
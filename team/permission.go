@@ -0,0 +1,48 @@
+package team
+
+var (
+	InvitePermission       = "invite"
+	KickPermission         = "kick"
+	ClaimPermission        = "claim"
+	UnclaimPermission      = "unclaim"
+	SetHomePermission      = "sethome"
+	WithdrawPermission     = "withdraw" // Not checked yet, there is no command to withdraw the team balance
+	AllyPermission         = "ally"     // Not checked yet, there is no command to manage the alliances
+	ContainersPermission   = "containers"
+	FriendlyFirePermission = "friendlyfire"
+)
+
+// defaultPermissions maps every action to the lowest role allowed to do it when the team did not change it
+var defaultPermissions = map[string]Role{
+	InvitePermission:       Officer,
+	KickPermission:         Officer,
+	ClaimPermission:        Officer,
+	UnclaimPermission:      Leader,
+	SetHomePermission:      Leader,
+	WithdrawPermission:     CoLeader,
+	AllyPermission:         CoLeader,
+	ContainersPermission:   Member,
+	FriendlyFirePermission: Officer,
+}
+
+// Permissions returns the actions of the permission matrix in the order they are displayed
+func Permissions() []string {
+	return []string{
+		InvitePermission,
+		KickPermission,
+		ClaimPermission,
+		UnclaimPermission,
+		SetHomePermission,
+		WithdrawPermission,
+		AllyPermission,
+		ContainersPermission,
+		FriendlyFirePermission,
+	}
+}
+
+// ValidPermission returns true if the action is part of the permission matrix
+func ValidPermission(action string) bool {
+	_, ok := defaultPermissions[action]
+
+	return ok
+}
@@ -3,10 +3,15 @@ package team
 import (
 	"errors"
 	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/bitrule/disrupt/team/tickable"
 )
@@ -15,15 +20,22 @@ import (
 type PlayerTeam struct {
 	tracker *Tracker
 
-	ownership string
-	hq        HQ
+	hqMu sync.RWMutex // Protects hq
+	hq   HQ
 
-	membersMu sync.RWMutex
+	membersMu sync.RWMutex // Protects members and ownership
 	members   map[string]Role
+	ownership string // XUID of the leader
 
 	invitesMu sync.RWMutex
 	invites   []string
 
+	alliesMu sync.RWMutex
+	allies   []string // Team IDs of the allied teams
+
+	permissionsMu sync.RWMutex
+	permissions   map[string]Role // Action -> Lowest role allowed, only the actions changed by the team
+
 	dtr *tickable.DTRTick
 }
 
@@ -33,15 +45,57 @@ func (t *PlayerTeam) Tracker() *Tracker {
 }
 
 func (t *PlayerTeam) Ownership() string {
+	t.membersMu.RLock()
+	defer t.membersMu.RUnlock()
+
 	return t.ownership
 }
 
+// TransferOwnership makes the member the leader of the team and gives the old leader the demoted role,
+// returns false if the player is not a member or is already the leader
+func (t *PlayerTeam) TransferOwnership(xuid string, demoted Role) bool {
+	t.membersMu.Lock()
+	defer t.membersMu.Unlock()
+
+	if r, ok := t.members[xuid]; !ok || r == Leader {
+		return false
+	}
+
+	if _, ok := t.members[t.ownership]; ok {
+		t.members[t.ownership] = demoted
+	}
+
+	t.members[xuid] = Leader
+	t.ownership = xuid
+
+	return true
+}
+
 func (t *PlayerTeam) HQ() HQ {
+	t.hqMu.RLock()
+	defer t.hqMu.RUnlock()
+
 	return t.hq
 }
 
 func (t *PlayerTeam) SetHQ(hq HQ) {
+	t.hqMu.Lock()
+	t.hq = hq
+	t.hqMu.Unlock()
+}
+
+// CompareAndSetHQ sets the hq only if the current one is still the old one, returns false if it changed meanwhile
+func (t *PlayerTeam) CompareAndSetHQ(old, hq HQ) bool {
+	t.hqMu.Lock()
+	defer t.hqMu.Unlock()
+
+	if t.hq != old {
+		return false
+	}
+
 	t.hq = hq
+
+	return true
 }
 
 func (t *PlayerTeam) Members() map[string]Role {
@@ -58,6 +112,20 @@ func (t *PlayerTeam) AddMember(xuid string, role Role) {
 	t.membersMu.Unlock()
 }
 
+// SetRole changes the role of a member of the team, returns false if the player is not a member
+func (t *PlayerTeam) SetRole(xuid string, role Role) bool {
+	t.membersMu.Lock()
+	defer t.membersMu.Unlock()
+
+	if _, ok := t.members[xuid]; !ok {
+		return false
+	}
+
+	t.members[xuid] = role
+
+	return true
+}
+
 // RemoveMember removes a member from the team
 func (t *PlayerTeam) RemoveMember(xuid string) {
 	t.membersMu.Lock()
@@ -95,6 +163,39 @@ func (t *PlayerTeam) DTR() *tickable.DTRTick {
 	return t.dtr
 }
 
+// MaxDTR returns the max DTR the team can reach based on its member count
+func (t *PlayerTeam) MaxDTR() float32 {
+	dtrConfig := config.DTRConfig()
+
+	return min(float32(len(t.Members()))*dtrConfig.PerMember, dtrConfig.Max)
+}
+
+// DoTick regenerates the DTR of the team and broadcasts its state changes.
+// This function should be called every tick.
+func (t *PlayerTeam) DoTick() {
+	dtrConfig := config.DTRConfig()
+	maxDTR := t.MaxDTR()
+
+	t.dtr.Regenerate(maxDTR, dtrConfig.Regen.Amount, time.Duration(dtrConfig.Regen.Interval)*time.Second)
+
+	state, changed := t.dtr.Transition(maxDTR)
+	if !changed {
+		return
+	}
+
+	dtr := strconv.FormatFloat(float64(t.dtr.Value()), 'f', 2, 32)
+
+	// Frozen is not broadcast, the member death broadcast already tells the team the DTR is frozen
+	switch state {
+	case tickable.DTRFull:
+		t.Broadcast(message.BroadcastTeamDTRFull.Build(dtr))
+	case tickable.DTRRegenerating:
+		t.Broadcast(message.BroadcastTeamDTRRegenerating.Build(dtr))
+	case tickable.DTRRaidable:
+		t.Broadcast(message.BroadcastTeamRaidable.Build(dtr))
+	}
+}
+
 // AddInvite adds an invitation to the team
 func (t *PlayerTeam) AddInvite(xuid string) {
 	t.invitesMu.Lock()
@@ -124,13 +225,138 @@ func (t *PlayerTeam) HasInvite(xuid string) bool {
 	return false
 }
 
+// Allies returns the IDs of the allied teams
+func (t *PlayerTeam) Allies() []string {
+	t.alliesMu.RLock()
+	defer t.alliesMu.RUnlock()
+
+	return slices.Clone(t.allies)
+}
+
+// AddAlly adds an allied team
+func (t *PlayerTeam) AddAlly(id string) {
+	t.alliesMu.Lock()
+	defer t.alliesMu.Unlock()
+
+	if !slices.Contains(t.allies, id) {
+		t.allies = append(t.allies, id)
+	}
+}
+
+// RemoveAlly removes an allied team
+func (t *PlayerTeam) RemoveAlly(id string) {
+	t.alliesMu.Lock()
+	defer t.alliesMu.Unlock()
+
+	if i := slices.Index(t.allies, id); i != -1 {
+		t.allies = slices.Delete(t.allies, i, i+1)
+	}
+}
+
+// IsAlly checks if the team is allied with another team
+func (t *PlayerTeam) IsAlly(id string) bool {
+	t.alliesMu.RLock()
+	defer t.alliesMu.RUnlock()
+
+	return slices.Contains(t.allies, id)
+}
+
+// Permission returns the lowest role allowed to do the action
+func (t *PlayerTeam) Permission(action string) Role {
+	t.permissionsMu.RLock()
+	defer t.permissionsMu.RUnlock()
+
+	if r, ok := t.permissions[action]; ok {
+		return r
+	}
+
+	if r, ok := defaultPermissions[action]; ok {
+		return r
+	}
+
+	return Leader
+}
+
+// SetPermission sets the lowest role allowed to do the action
+func (t *PlayerTeam) SetPermission(action string, role Role) {
+	t.permissionsMu.Lock()
+	defer t.permissionsMu.Unlock()
+
+	if t.permissions == nil {
+		t.permissions = make(map[string]Role)
+	}
+
+	if defaultPermissions[action] == role {
+		delete(t.permissions, action)
+	} else {
+		t.permissions[action] = role
+	}
+}
+
+// Can returns true if the member has a role high enough to do the action
+func (t *PlayerTeam) Can(xuid string, action string) bool {
+	r := t.Member(xuid)
+
+	return r != Undefined && !r.LowestThan(t.Permission(action))
+}
+
 // Unmarshal loads the monitor's configuration from a map
 func (t *PlayerTeam) Unmarshal(body map[string]interface{}) error {
-	invites, ok := body["invites"].([]string)
+	ownership, ok := body["ownership"].(string)
+	if !ok {
+		return errors.New("missing ownership")
+	}
+	t.ownership = ownership
+
+	membersProp, ok := body["members"].(map[string]interface{})
 	if !ok {
-		return errors.New("missing invites")
+		return errors.New("missing members")
+	}
+
+	t.members = make(map[string]Role, len(membersProp))
+	for xuid, role := range membersProp {
+		roleName, ok := role.(string)
+		if !ok {
+			return errors.New("invalid role of member " + xuid)
+		}
+
+		t.members[xuid] = RoleFromName(roleName)
+	}
+
+	// Invites are stored as an array, which is decoded as primitive.A
+	if invites, ok := body["invites"].(primitive.A); ok {
+		for _, xuid := range invites {
+			if xuid, ok := xuid.(string); ok {
+				t.invites = append(t.invites, xuid)
+			}
+		}
+	}
+
+	// Teams saved before alliances existed have no allies
+	if allies, ok := body["allies"].(primitive.A); ok {
+		for _, id := range allies {
+			if id, ok := id.(string); ok {
+				t.allies = append(t.allies, id)
+			}
+		}
+	}
+
+	// Teams saved before the permission matrix existed use the default permissions
+	if permissions, ok := body["permissions"].(map[string]interface{}); ok {
+		t.permissions = make(map[string]Role, len(permissions))
+		for action, role := range permissions {
+			if roleName, ok := role.(string); ok && ValidPermission(action) {
+				t.permissions[action] = RoleFromName(roleName)
+			}
+		}
+	}
+
+	// Teams without a home have no hq
+	if hqProp, ok := body["hq"].(map[string]interface{}); ok {
+		if err := t.hq.Unmarshal(hqProp); err != nil {
+			return errors.Join(errors.New("failed to unmarshal HQ: "), err)
+		}
 	}
-	t.invites = invites
 
 	dtrProp, ok := body["dtr"].(map[string]interface{})
 	if !ok {
@@ -156,16 +382,29 @@ func (t *PlayerTeam) Marshal() (map[string]interface{}, error) {
 	body := make(map[string]interface{})
 	body["tracker"] = t.tracker.Marshal()
 
-	if t.hq.loaded {
-		body["hq"] = t.hq.Marshal()
+	if hq := t.HQ(); hq.loaded {
+		body["hq"] = hq.Marshal()
 	}
 
-	body["ownership"] = t.ownership
-
 	t.invitesMu.RLock()
-	body["invites"] = t.invites
+	body["invites"] = slices.Clone(t.invites)
 	t.invitesMu.RUnlock()
 
+	t.alliesMu.RLock()
+	body["allies"] = slices.Clone(t.allies)
+	t.alliesMu.RUnlock()
+
+	t.permissionsMu.RLock()
+
+	// Wrap the permission roles in a map of actions to role names
+	permissions := make(map[string]string, len(t.permissions))
+	for action, role := range t.permissions {
+		permissions[action] = role.Name()
+	}
+
+	body["permissions"] = permissions
+	t.permissionsMu.RUnlock()
+
 	t.membersMu.RLock()
 
 	// Wrap the members roles in a map of XUIDs to role names
@@ -175,6 +414,7 @@ func (t *PlayerTeam) Marshal() (map[string]interface{}, error) {
 	}
 
 	body["members"] = members
+	body["ownership"] = t.ownership
 	t.membersMu.RUnlock()
 
 	if dtrData, err := t.dtr.Marshal(); err != nil {
@@ -200,5 +440,6 @@ func NewPlayerTeam(ownership, name string) *PlayerTeam {
 		members: map[string]Role{
 			ownership: Leader,
 		},
+		dtr: tickable.NewDTRTick(min(config.DTRConfig().PerMember, config.DTRConfig().Max)),
 	}
 }
@@ -0,0 +1,163 @@
+package team
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestTransferOwnership(t *testing.T) {
+	pt := NewPlayerTeam("leader", "Alpha")
+	pt.AddMember("officer", Officer)
+
+	if pt.TransferOwnership("stranger", Officer) {
+		t.Fatal("transferred the leadership to a player outside the team")
+	}
+
+	if pt.TransferOwnership("leader", Officer) {
+		t.Fatal("transferred the leadership to the current leader")
+	}
+
+	if !pt.TransferOwnership("officer", CoLeader) {
+		t.Fatal("did not transfer the leadership to a member")
+	}
+
+	if pt.Ownership() != "officer" || pt.Member("officer") != Leader {
+		t.Fatalf("expected the officer to lead the team, got %s as %s", pt.Ownership(), pt.Member("officer").Name())
+	}
+
+	if got := pt.Member("leader"); got != CoLeader {
+		t.Fatalf("expected the old leader to be demoted to Co-Leader, got %s", got.Name())
+	}
+}
+
+func TestCanDefaultPermissions(t *testing.T) {
+	pt := NewPlayerTeam("leader", "Alpha")
+	pt.AddMember("co-leader", CoLeader)
+	pt.AddMember("officer", Officer)
+	pt.AddMember("member", Member)
+
+	tests := []struct {
+		xuid   string
+		action string
+		want   bool
+	}{
+		{"leader", UnclaimPermission, true},
+		{"co-leader", UnclaimPermission, false},
+		{"officer", InvitePermission, true},
+		{"member", InvitePermission, false},
+		{"member", ContainersPermission, true},
+		{"stranger", ContainersPermission, false},
+		{"officer", "unknown", false}, // Actions outside the matrix are only for the leader
+		{"leader", "unknown", true},
+	}
+
+	for _, test := range tests {
+		if got := pt.Can(test.xuid, test.action); got != test.want {
+			t.Errorf("%s can %s: expected %t, got %t", test.xuid, test.action, test.want, got)
+		}
+	}
+}
+
+func TestSetPermission(t *testing.T) {
+	pt := NewPlayerTeam("leader", "Alpha")
+	pt.AddMember("member", Member)
+
+	pt.SetPermission(KickPermission, Member)
+	if !pt.Can("member", KickPermission) {
+		t.Fatal("the member cannot kick after lowering the permission")
+	}
+
+	pt.SetPermission(ContainersPermission, Leader)
+	if pt.Can("member", ContainersPermission) {
+		t.Fatal("the member can open containers after raising the permission")
+	}
+
+	// Going back to the default must not keep the action as changed
+	pt.SetPermission(KickPermission, defaultPermissions[KickPermission])
+	if _, ok := pt.permissions[KickPermission]; ok {
+		t.Fatal("the default permission was stored as a change")
+	}
+}
+
+func TestDefaultPermissionsMatrix(t *testing.T) {
+	if len(Permissions()) != len(defaultPermissions) {
+		t.Fatalf("expected %d actions, got %d", len(defaultPermissions), len(Permissions()))
+	}
+
+	for _, action := range Permissions() {
+		if !ValidPermission(action) {
+			t.Errorf("%s is listed but has no default", action)
+		}
+	}
+}
+
+func TestPlayerTeamRoundTrip(t *testing.T) {
+	pt := NewPlayerTeam("leader", "Alpha")
+	pt.AddMember("co-leader", CoLeader)
+	pt.AddMember("member", Member)
+	pt.AddInvite("invited")
+	pt.AddAlly("ally-id")
+	pt.SetPermission(ClaimPermission, Member)
+	pt.SetHQ(HQ{wName: "World", dim: world.Nether, pos: mgl64.Vec3{1, 2, 3}, loaded: true})
+
+	body, err := pt.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	decoded, err := Unmarshal(roundTrip(t, body))
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	got, ok := decoded.(*PlayerTeam)
+	if !ok {
+		t.Fatalf("expected a player team, got %T", decoded)
+	}
+
+	if got.Tracker().Id() != pt.Tracker().Id() || got.Ownership() != "leader" {
+		t.Fatalf("identity mismatch: %s led by %s", got.Tracker().Id(), got.Ownership())
+	}
+
+	if !reflect.DeepEqual(got.Members(), pt.Members()) {
+		t.Fatalf("expected members %v, got %v", pt.Members(), got.Members())
+	}
+
+	if !got.HasInvite("invited") || !got.IsAlly("ally-id") {
+		t.Fatal("the invites or the allies were lost")
+	}
+
+	if got.Permission(ClaimPermission) != Member || got.Permission(KickPermission) != defaultPermissions[KickPermission] {
+		t.Fatalf("permissions mismatch: claim %s, kick %s", got.Permission(ClaimPermission).Name(), got.Permission(KickPermission).Name())
+	}
+
+	if got.HQ() != pt.HQ() {
+		t.Fatalf("expected HQ %+v, got %+v", pt.HQ(), got.HQ())
+	}
+}
+
+func TestPlayerTeamUnmarshalDropsUnknownPermissions(t *testing.T) {
+	body, err := NewPlayerTeam("leader", "Alpha").Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	body["permissions"] = map[string]string{"fly": Member.Name(), InvitePermission: Member.Name()}
+
+	decoded, err := Unmarshal(roundTrip(t, body))
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	got := decoded.(*PlayerTeam)
+	if _, ok := got.permissions["fly"]; ok {
+		t.Fatal("kept a permission outside the matrix")
+	}
+
+	if got.Permission(InvitePermission) != Member {
+		t.Fatalf("expected members to invite, got %s", got.Permission(InvitePermission).Name())
+	}
+}
@@ -3,6 +3,8 @@ package team
 import (
 	"errors"
 	"github.com/bitrule/disrupt/team/tickable"
+	"github.com/google/uuid"
+	"sync/atomic"
 )
 
 type SystemTeam struct {
@@ -15,14 +17,91 @@ func (t *SystemTeam) Tracker() *Tracker {
 	return t.tracker
 }
 
+// Tick returns the tick of the team, nil if the team does not tick
+func (t *SystemTeam) Tick() tickable.Tick {
+	return t.tick
+}
+
+// SetTick sets the tick of the team
+func (t *SystemTeam) SetTick(tick tickable.Tick) {
+	t.tick = tick
+}
+
+// KoTH returns the KoTH tick of the team, ok is false if the team is not a KoTH
+func (t *SystemTeam) KoTH() (kt *tickable.KoTHTick, ok bool) {
+	kt, ok = t.tick.(*tickable.KoTHTick)
+	return kt, ok
+}
+
 func (t *SystemTeam) DoTick() {
-	t.tick.DoTick()
+	if t.tick != nil {
+		t.tick.DoTick()
+	}
 }
 
+// Marshal saves the team's tracker and tick to a map
 func (t *SystemTeam) Marshal() (map[string]interface{}, error) {
-	return nil, errors.New("not implemented")
+	body := make(map[string]interface{})
+	body["tracker"] = t.tracker.Marshal()
+
+	if t.tick != nil {
+		tickData, err := t.tick.Marshal()
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to marshal "+t.tick.Type()+" tick: "), err)
+		}
+
+		body["tick"] = map[string]interface{}{
+			"type": t.tick.Type(),
+			"data": tickData,
+		}
+	}
+
+	return body, nil
 }
 
-func (t *SystemTeam) Unmarshal(prop map[string]interface{}) error {
-	return errors.New("not implemented")
+// Unmarshal loads the team's tick from a map, the tracker is loaded by team.Unmarshal
+func (t *SystemTeam) Unmarshal(body map[string]interface{}) error {
+	tickProp, ok := body["tick"].(map[string]interface{})
+	if !ok {
+		// System teams without a tick
+		return nil
+	}
+
+	tickType, ok := tickProp["type"].(string)
+	if !ok {
+		return errors.New("missing tick type")
+	}
+
+	tickData, ok := tickProp["data"].(map[string]interface{})
+	if !ok {
+		return errors.New("missing tick data")
+	}
+
+	tick, ok := tickable.New(tickType)
+	if !ok {
+		return errors.New("unknown tick type '" + tickType + "'")
+	}
+
+	if err := tick.Unmarshal(tickData); err != nil {
+		return errors.Join(errors.New("failed to unmarshal "+tickType+" tick: "), err)
+	}
+
+	t.tick = tick
+
+	return nil
+}
+
+func NewSystemTeam(name string) *SystemTeam {
+	return &SystemTeam{
+		tracker: &Tracker{
+			id:       uuid.New().String(),
+			name:     name,
+			teamType: SystemTeamType,
+
+			balance: atomic.Int32{},
+			points:  atomic.Int32{},
+
+			options: make(map[string]interface{}),
+		},
+	}
 }
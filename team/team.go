@@ -11,10 +11,12 @@ var (
     SystemTeamType = "System"
     PlayerTeamType = "Player"
 
+    // Roles are persisted by their name, so they can be renumbered safely
     Leader    = Role(0)
-    Officer   = Role(1)
-    Member    = Role(2)
-    Undefined = Role(3)
+    CoLeader  = Role(1)
+    Officer   = Role(2)
+    Member    = Role(3)
+    Undefined = Role(4)
 )
 
 type Team interface {
@@ -67,6 +69,8 @@ func (r Role) Name() string {
     switch r {
     case Leader:
         return "Leader"
+    case CoLeader:
+        return "Co-Leader"
     case Officer:
         return "Officer"
     case Member:
@@ -80,6 +84,8 @@ func RoleFromName(name string) Role {
     switch name {
     case "Leader":
         return Leader
+    case "Co-Leader":
+        return CoLeader
     case "Officer":
         return Officer
     case "Member":
@@ -89,6 +95,26 @@ func RoleFromName(name string) Role {
     return Member
 }
 
+// Promoted returns the role above the current one, ok is false if the role cannot be promoted
+// because the leadership can only be transferred.
+func (r Role) Promoted() (Role, bool) {
+    if r <= CoLeader || r > Member {
+        return r, false
+    }
+
+    return r - 1, true
+}
+
+// Demoted returns the role below the current one, ok is false if the role cannot be demoted
+// because it is the lowest one or the leader.
+func (r Role) Demoted() (Role, bool) {
+    if r == Leader || r >= Member {
+        return r, false
+    }
+
+    return r + 1, true
+}
+
 // HighestThan returns true if the other role is higher than the current role
 // because if the role id is higher, the role priority is lower.
 func (r Role) HighestThan(other Role) bool {
@@ -0,0 +1,71 @@
+package team
+
+import "testing"
+
+func TestRolePromoted(t *testing.T) {
+	tests := []struct {
+		role Role
+		want Role
+		ok   bool
+	}{
+		{Member, Officer, true},
+		{Officer, CoLeader, true},
+		{CoLeader, CoLeader, false}, // The leadership can only be transferred
+		{Leader, Leader, false},
+		{Undefined, Undefined, false},
+	}
+
+	for _, test := range tests {
+		if got, ok := test.role.Promoted(); got != test.want || ok != test.ok {
+			t.Errorf("%s promoted: expected %s %t, got %s %t", test.role.Name(), test.want.Name(), test.ok, got.Name(), ok)
+		}
+	}
+}
+
+func TestRoleDemoted(t *testing.T) {
+	tests := []struct {
+		role Role
+		want Role
+		ok   bool
+	}{
+		{CoLeader, Officer, true},
+		{Officer, Member, true},
+		{Member, Member, false},
+		{Leader, Leader, false},
+		{Undefined, Undefined, false},
+	}
+
+	for _, test := range tests {
+		if got, ok := test.role.Demoted(); got != test.want || ok != test.ok {
+			t.Errorf("%s demoted: expected %s %t, got %s %t", test.role.Name(), test.want.Name(), test.ok, got.Name(), ok)
+		}
+	}
+}
+
+func TestRoleHighestThan(t *testing.T) {
+	order := []Role{Leader, CoLeader, Officer, Member, Undefined}
+
+	for i, role := range order {
+		for j, other := range order {
+			if got := role.HighestThan(other); got != (i < j) {
+				t.Errorf("%s highest than %s: expected %t", role.Name(), other.Name(), i < j)
+			}
+
+			if got := role.LowestThan(other); got != (i > j) {
+				t.Errorf("%s lowest than %s: expected %t", role.Name(), other.Name(), i > j)
+			}
+		}
+	}
+}
+
+func TestRoleFromName(t *testing.T) {
+	for _, role := range []Role{Leader, CoLeader, Officer, Member} {
+		if got := RoleFromName(role.Name()); got != role {
+			t.Errorf("expected %s, got %s", role.Name(), got.Name())
+		}
+	}
+
+	if got := RoleFromName("Captain"); got != Member {
+		t.Errorf("expected unknown roles to load as members, got %s", got.Name())
+	}
+}
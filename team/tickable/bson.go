@@ -0,0 +1,44 @@
+package tickable
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// marshalBBox returns the bounding box as a map of its min and max corners.
+func marshalBBox(bbox cube.BBox) map[string]interface{} {
+	minVec, maxVec := bbox.Min(), bbox.Max()
+
+	return map[string]interface{}{
+		"min": []float64{minVec[0], minVec[1], minVec[2]},
+		"max": []float64{maxVec[0], maxVec[1], maxVec[2]},
+	}
+}
+
+// unmarshalBBox decodes a bounding box stored as a map of its min and max corners.
+func unmarshalBBox(v interface{}) (cube.BBox, error) {
+	body, ok := v.(map[string]interface{})
+	if !ok {
+		return cube.BBox{}, errors.New("bbox is not a map")
+	}
+
+	var corners [2][3]float64
+	for i, key := range []string{"min", "max"} {
+		arr, ok := body[key].(primitive.A)
+		if !ok || len(arr) != 3 {
+			return cube.BBox{}, errors.New("bbox " + key + " is not an array of 3 elements")
+		}
+
+		for j, e := range arr {
+			f, ok := e.(float64)
+			if !ok {
+				return cube.BBox{}, errors.New("bbox " + key + " element is not a float64")
+			}
+
+			corners[i][j] = f
+		}
+	}
+
+	return cube.Box(corners[0][0], corners[0][1], corners[0][2], corners[1][0], corners[1][1], corners[1][2]), nil
+}
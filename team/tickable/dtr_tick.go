@@ -2,28 +2,94 @@ package tickable
 
 import (
     "errors"
+    "sync"
     "time"
 )
 
+var (
+    DTRFull         = DTRState(0) // The DTR reached the max value of the team
+    DTRRegenerating = DTRState(1) // The DTR is below the max value and regenerating
+    DTRFrozen       = DTRState(2) // The DTR is frozen after a member death
+    DTRRaidable     = DTRState(3) // The DTR is at or below zero, so the team can be raided
+
+    dtrUnknown = DTRState(-1) // The state was not stored, so the next one is taken without a transition
+)
+
+type DTRState int // DTRState is a type that represents the state of the DTR of a team.
+
+// Name returns the name of the DTR state
+func (s DTRState) Name() string {
+    switch s {
+    case DTRFull:
+        return "Full"
+    case DTRRegenerating:
+        return "Regenerating"
+    case DTRFrozen:
+        return "Frozen"
+    case DTRRaidable:
+        return "Raidable"
+    }
+
+    return "Unknown"
+}
+
+// DTRStateFromName returns the DTR state with the given name, ok is false if there is none
+func DTRStateFromName(name string) (DTRState, bool) {
+    for _, s := range []DTRState{DTRFull, DTRRegenerating, DTRFrozen, DTRRaidable} {
+        if s.Name() == name {
+            return s, true
+        }
+    }
+
+    return dtrUnknown, false
+}
+
 type DTRTick struct {
+    mu sync.RWMutex // Protects the fields below
+
     value float32
 
     lastUpdated time.Time
     frozenUntil time.Time
+
+    state DTRState // Last state seen by Transition, persisted so a restart does not repeat the transition
+}
+
+// NewDTRTick returns a new DTR tick with the given value
+func NewDTRTick(value float32) *DTRTick {
+    return &DTRTick{
+        value:       value,
+        lastUpdated: time.Now(),
+    }
 }
 
 // Value returns the value of the DTR tick
 func (m *DTRTick) Value() float32 {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+
     return m.value
 }
 
 // SetValue sets the value of the DTR tick
 func (m *DTRTick) SetValue(value float32) {
+    m.mu.Lock()
     m.value = value
+    m.mu.Unlock()
+}
+
+// Decrease takes the amount away from the DTR value, which never goes below minValue
+func (m *DTRTick) Decrease(amount, minValue float32) {
+    m.mu.Lock()
+    m.value = max(m.value-amount, minValue)
+    m.mu.Unlock()
 }
 
 // UpdateRemaining updates the remaining time until the DTR tick is unfrozen
 func (m *DTRTick) UpdateRemaining(seconds int64) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
     m.frozenUntil = time.Now().Add(time.Duration(seconds) * time.Second)
 
     m.lastUpdated = time.Now()
@@ -31,21 +97,100 @@ func (m *DTRTick) UpdateRemaining(seconds int64) {
 
 // Remaining returns the remaining time until the DTR tick is unfrozen
 func (m *DTRTick) Remaining() time.Duration {
-    if m.frozenUntil.UnixMilli() == 0 {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+
+    return m.remaining()
+}
+
+// remaining returns the remaining time until the DTR tick is unfrozen, the caller must hold the lock
+func (m *DTRTick) remaining() time.Duration {
+    if m.frozenUntil.UnixMilli() <= 0 {
         return 0
     }
 
-    return time.Until(m.frozenUntil)
+    return max(time.Until(m.frozenUntil), 0)
+}
+
+// Raidable returns true if the DTR is at or below zero
+func (m *DTRTick) Raidable() bool {
+    return m.Value() <= 0
+}
+
+// State returns the state of the DTR tick based on the max value the DTR can reach
+func (m *DTRTick) State(maxValue float32) DTRState {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+
+    return m.stateOf(maxValue)
+}
+
+// stateOf returns the state of the DTR tick, the caller must hold the lock
+func (m *DTRTick) stateOf(maxValue float32) DTRState {
+    if m.value <= 0 {
+        return DTRRaidable
+    } else if m.remaining() > 0 {
+        return DTRFrozen
+    } else if m.value < maxValue {
+        return DTRRegenerating
+    }
+
+    return DTRFull
+}
+
+// Transition returns the current state of the DTR tick and true if it is different
+// from the state returned the last time Transition was called
+func (m *DTRTick) Transition(maxValue float32) (DTRState, bool) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    state := m.stateOf(maxValue)
+    if state == m.state {
+        return state, false
+    }
+
+    known := m.state != dtrUnknown
+    m.state = state
+
+    return state, known
+}
+
+// Regenerate adds the amount to the DTR value once it is no longer frozen and the interval has passed
+// since the last update. The value never exceeds maxValue, if it does, it is capped.
+// Returns true if the value has changed.
+func (m *DTRTick) Regenerate(maxValue, amount float32, interval time.Duration) bool {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    if m.value > maxValue {
+        // The max value decreases when a member leaves the team
+        m.value = maxValue
+
+        return true
+    }
+
+    if m.value == maxValue || m.remaining() > 0 || time.Since(m.lastUpdated) < interval {
+        return false
+    }
+
+    m.value = min(m.value+amount, maxValue)
+    m.lastUpdated = time.Now()
+
+    return true
 }
 
 // Unmarshal unmarshals the DTR tick from a map
 func (m *DTRTick) Unmarshal(body map[string]interface{}) error {
-    value, ok := body["value"].(float32)
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    // The value is stored as a double into our database
+    value, ok := body["value"].(float64)
     if !ok {
         return errors.New("missing DTR value")
     }
 
-    m.value = value
+    m.value = float32(value)
 
     lastUpdated, ok := body["lastUpdated"].(int64)
     if !ok {
@@ -61,13 +206,23 @@ func (m *DTRTick) Unmarshal(body map[string]interface{}) error {
 
     m.frozenUntil = time.UnixMilli(frozenUntil)
 
+    // Ticks saved before the state was stored take their current state silently
+    m.state = dtrUnknown
+    if name, ok := body["state"].(string); ok {
+        m.state, _ = DTRStateFromName(name)
+    }
+
     return nil
 }
 
 func (m *DTRTick) Marshal() (map[string]interface{}, error) {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+
     return map[string]interface{}{
         "value":       m.value,
         "lastUpdated": m.lastUpdated.UnixMilli(),
         "frozenUntil": m.frozenUntil.UnixMilli(),
+        "state":       m.state.Name(),
     }, nil
 }
@@ -1,67 +1,378 @@
 package tickable
 
 import (
+    "errors"
     "github.com/bitrule/disrupt"
-    "github.com/bitrule/disrupt/service"
+    "github.com/bitrule/disrupt/config"
+    "github.com/bitrule/disrupt/message"
     "github.com/df-mc/dragonfly/server/block/cube"
+    "github.com/df-mc/dragonfly/server/item"
     "github.com/df-mc/dragonfly/server/player"
+    "github.com/df-mc/dragonfly/server/player/chat"
+    "github.com/df-mc/dragonfly/server/world"
+    "slices"
+    "sync"
     "time"
 )
 
+// KoTHEnv resolves what the KoTH tick needs from the services, which cannot be imported from the tickable package.
+// None of its functions are called while the lock of the tick is held.
+type KoTHEnv interface {
+    // Name returns the name of the KoTH team
+    Name(teamId string) string
+    // World returns the world of the KoTH team land the capture zone is in, nil if it is outside the land
+    World(teamId string, bbox cube.BBox) *world.World
+    // Capable returns true if the player is a member of a player team, so they can capture the KoTH
+    Capable(xuid string) bool
+    // UserName returns the name of the user, empty if it is unknown
+    UserName(xuid string) string
+    // AwardPoints gives the points to the team of the player and returns its name, ok is false if the player is not in a team
+    AwardPoints(xuid string, points int32) (name string, ok bool)
+}
+
 type KoTHTick struct {
-    teamId string
+    teamId string // Never changes after the tick is created or unmarshalled
+
+    mu sync.Mutex // Protects the fields below
+
+    env KoTHEnv
 
     duration time.Duration // Duration of the KoTH
     bbox     cube.BBox
 
+    active bool // Whether the KoTH is running
+
     capturingAt time.Time // Time the KoTH is being captured
     capturingBy string    // Player capturing the KoTH
+
+    lastBroadcast int64 // Remaining seconds of the last progress broadcast
+}
+
+// NewKoTHTick returns a new KoTH tick for the team with the given duration and capture zone.
+func NewKoTHTick(teamId string, duration time.Duration, bbox cube.BBox) *KoTHTick {
+    return &KoTHTick{
+        teamId:   teamId,
+        duration: duration,
+        bbox:     bbox,
+    }
+}
+
+// SetEnv sets the environment the KoTH resolves its team, world and players from.
+// The KoTH does not tick until it has one.
+func (kt *KoTHTick) SetEnv(env KoTHEnv) {
+    kt.mu.Lock()
+    kt.env = env
+    kt.mu.Unlock()
+}
+
+// Duration returns the time a player must hold the KoTH to capture it.
+func (kt *KoTHTick) Duration() time.Duration {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return kt.duration
+}
+
+// SetDuration sets the time a player must hold the KoTH to capture it.
+func (kt *KoTHTick) SetDuration(duration time.Duration) {
+    kt.mu.Lock()
+    kt.duration = duration
+    kt.mu.Unlock()
+}
+
+// BBox returns the capture zone of the KoTH.
+func (kt *KoTHTick) BBox() cube.BBox {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return kt.bbox
+}
+
+// SetBBox sets the capture zone of the KoTH.
+func (kt *KoTHTick) SetBBox(bbox cube.BBox) {
+    kt.mu.Lock()
+    kt.bbox = bbox
+    kt.mu.Unlock()
+}
+
+// Active returns true if the KoTH is running.
+func (kt *KoTHTick) Active() bool {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return kt.active
+}
+
+// Capper returns the XUID of the player capturing the KoTH, empty if nobody is capturing it.
+func (kt *KoTHTick) Capper() string {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return kt.capturingBy
 }
 
 // Remaining returns the remaining time of the KoTH.
 func (kt *KoTHTick) Remaining() time.Duration {
-    return kt.duration - time.Since(kt.capturingAt)
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return kt.remaining()
 }
 
-// Tick ticks the KoTH.
-func (kt *KoTHTick) Tick() {
-    t := service.Team().LookupById(kt.teamId)
-    if t == nil {
-        disrupt.Log.Panic("KoTH team not found")
+// remaining returns the remaining time of the KoTH, the caller must hold the lock.
+func (kt *KoTHTick) remaining() time.Duration {
+    if kt.capturingBy == "" {
+        return kt.duration
+    }
 
+    return max(kt.duration-time.Since(kt.capturingAt), 0)
+}
+
+// Start starts the KoTH and broadcasts it to the server.
+// Returns false if the KoTH was already running.
+func (kt *KoTHTick) Start() bool {
+    kt.mu.Lock()
+    if kt.active {
+        kt.mu.Unlock()
+
+        return false
+    }
+
+    kt.active = true
+    kt.reset()
+
+    env := kt.env
+    kt.mu.Unlock()
+
+    broadcast(message.BroadcastKoTHStarted.Build(kt.name(env)))
+
+    return true
+}
+
+// Stop stops the KoTH without a winner and broadcasts it to the server.
+// Returns false if the KoTH was not running.
+func (kt *KoTHTick) Stop() bool {
+    kt.mu.Lock()
+    if !kt.active {
+        kt.mu.Unlock()
+
+        return false
+    }
+
+    kt.active = false
+    kt.reset()
+
+    env := kt.env
+    kt.mu.Unlock()
+
+    broadcast(message.BroadcastKoTHStopped.Build(kt.name(env)))
+
+    return true
+}
+
+// DoTick ticks the KoTH.
+// It keeps track of the player capturing the KoTH, knocks them off when they leave the zone or die
+// and finishes the capture once the remaining time reaches zero.
+// The state is read under the lock and the environment is only used once it is released.
+func (kt *KoTHTick) DoTick() {
+    kt.mu.Lock()
+    active, env, bbox, capper := kt.active, kt.env, kt.bbox, kt.capturingBy
+    kt.mu.Unlock()
+
+    if !active || env == nil {
+        return
+    }
+
+    w := env.World(kt.teamId, bbox)
+    if w == nil {
         return
     }
 
-    for wName := range t.Tracker().Cuboids() {
-        w := service.World().LookupByName(wName)
-        if w == nil {
-            disrupt.Log.WithField("world", wName).Error("KoTH world not found")
+    name := kt.name(env)
+
+    if capper != "" {
+        p, ok := disrupt.SRV.PlayerByXUID(capper)
+        if !ok || p.Dead() || p.World() != w || !bbox.Vec3Within(p.Position()) {
+            if kt.knock(capper) {
+                broadcast(message.BroadcastKoTHKnocked.Build(env.UserName(capper), name))
+            }
+        } else if kt.finish(capper) {
+            kt.capture(env, p, name)
+
+            return
+        } else {
+            kt.broadcastProgress(name)
+
+            return
+        }
+    }
 
+    for _, e := range w.EntitiesWithin(bbox, nil) {
+        p, ok := e.(*player.Player)
+        if !ok || p.Dead() || !env.Capable(p.XUID()) {
             continue
         }
 
-        if kt.capturingBy != "" {
-            if p, ok := disrupt.SRV.PlayerByXUID(kt.capturingBy); ok && p.World() == w && kt.bbox.Vec3Within(p.Position()) {
-                return
-            }
+        if duration, ok := kt.startCapture(p.XUID()); ok {
+            p.Message(message.SuccessSelfKoTHCapping.Build(name))
+            broadcast(message.BroadcastKoTHCapping.Build(name, duration.String()))
         }
 
-        for _, e := range w.EntitiesWithin(kt.bbox, nil) {
-            if p, ok := e.(*player.Player); ok {
-                pt := service.Team().LookupByMember(p.XUID())
-                if pt == nil {
-                    continue
-                }
+        break
+    }
+}
+
+// knock clears the capture if the player is still capturing the KoTH, returns false otherwise.
+func (kt *KoTHTick) knock(capper string) bool {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
 
-                kt.capturingAt = time.Now()
-                kt.capturingBy = p.XUID()
+    if !kt.active || kt.capturingBy != capper {
+        return false
+    }
 
-                // TODO: Broadcast message
+    kt.reset()
 
-                break
-            }
+    return true
+}
+
+// finish stops the KoTH if the player is still capturing it and the remaining time reached zero,
+// returns false otherwise. Only one tick can finish the capture.
+func (kt *KoTHTick) finish(capper string) bool {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    if !kt.active || kt.capturingBy != capper || kt.remaining() > 0 {
+        return false
+    }
+
+    kt.active = false
+    kt.reset()
+
+    return true
+}
+
+// startCapture makes the player the capper of the KoTH if nobody is capturing it,
+// also returns the time the player must hold it for.
+func (kt *KoTHTick) startCapture(xuid string) (time.Duration, bool) {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    if !kt.active || kt.capturingBy != "" {
+        return 0, false
+    }
+
+    kt.capturingAt = time.Now()
+    kt.capturingBy = xuid
+
+    return kt.duration, true
+}
+
+// capture awards the points to the capper's team and the rewards to the capper.
+func (kt *KoTHTick) capture(env KoTHEnv, p *player.Player, name string) {
+    kothConfig := config.KoTHConfig()
+
+    teamName, ok := env.AwardPoints(p.XUID(), kothConfig.Points)
+    if !ok {
+        teamName = "None"
+    }
+
+    for _, reward := range kothConfig.Rewards {
+        it, ok := world.ItemByName(reward.Item, reward.Meta)
+        if !ok {
+            disrupt.Log.WithField("item", reward.Item).Error("KoTH reward item not found")
+
+            continue
+        }
+
+        if _, err := p.Inventory().AddItem(item.NewStack(it, reward.Count)); err != nil {
+            // Drop the reward on the ground if the inventory is full
+            p.Drop(item.NewStack(it, reward.Count))
         }
     }
 
-    // TODO: Tick capturing
+    broadcast(message.BroadcastKoTHCaptured.Build(p.Name(), teamName, name))
+}
+
+// broadcastProgress broadcasts the remaining time of the capture if it matches any of the configured intervals.
+func (kt *KoTHTick) broadcastProgress(name string) {
+    kt.mu.Lock()
+    seconds := int64(kt.remaining().Seconds())
+    if seconds == kt.lastBroadcast || !slices.Contains(config.KoTHConfig().BroadcastIntervals, seconds) {
+        kt.mu.Unlock()
+
+        return
+    }
+
+    kt.lastBroadcast = seconds
+    kt.mu.Unlock()
+
+    broadcast(message.BroadcastKoTHRemaining.Build(name, (time.Duration(seconds) * time.Second).String()))
+}
+
+// Type returns the name the KoTH tick type is registered with.
+func (*KoTHTick) Type() string {
+    return KoTHType
+}
+
+// Marshal returns the KoTH tick as a map.
+// The capture state is not stored, so a running KoTH is stopped after a restart.
+func (kt *KoTHTick) Marshal() (map[string]interface{}, error) {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    return map[string]interface{}{
+        "teamId":   kt.teamId,
+        "duration": kt.duration.Milliseconds(),
+        "bbox":     marshalBBox(kt.bbox),
+    }, nil
+}
+
+// Unmarshal unmarshals the KoTH tick from a map.
+func (kt *KoTHTick) Unmarshal(body map[string]interface{}) error {
+    kt.mu.Lock()
+    defer kt.mu.Unlock()
+
+    teamId, ok := body["teamId"].(string)
+    if !ok {
+        return errors.New("missing KoTH team ID")
+    }
+    kt.teamId = teamId
+
+    duration, ok := body["duration"].(int64)
+    if !ok {
+        return errors.New("missing KoTH duration")
+    }
+    kt.duration = time.Duration(duration) * time.Millisecond
+
+    bbox, err := unmarshalBBox(body["bbox"])
+    if err != nil {
+        return errors.Join(errors.New("invalid KoTH bbox: "), err)
+    }
+    kt.bbox = bbox
+
+    return nil
+}
+
+// reset clears the capture state of the KoTH.
+func (kt *KoTHTick) reset() {
+    kt.capturingAt = time.Time{}
+    kt.capturingBy = ""
+    kt.lastBroadcast = 0
+}
+
+// name returns the name of the KoTH team, the team ID if the environment is not set.
+func (kt *KoTHTick) name(env KoTHEnv) string {
+    if env == nil {
+        return kt.teamId
+    }
+
+    return env.Name(kt.teamId)
+}
+
+// broadcast sends the message to every player on the server.
+func broadcast(msg string) {
+    if _, err := chat.Global.WriteString(msg); err != nil {
+        disrupt.Log.WithError(err).Error("failed to broadcast KoTH message")
+    }
 }
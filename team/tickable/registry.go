@@ -0,0 +1,51 @@
+package tickable
+
+import "sync"
+
+var (
+	KoTHType  = "koth"
+	SpawnType = "spawn"
+	RoadType  = "road"
+)
+
+// Factory returns an empty tick of a type, ready to be unmarshalled.
+type Factory func() Tick
+
+var (
+	factoriesMu sync.RWMutex       // Protects factories
+	factories   map[string]Factory // Tick type name -> Factory
+)
+
+// Register registers a tick type with the factory used to create it.
+// Registering a name twice replaces the previous factory.
+func Register(name string, f Factory) {
+	factoriesMu.Lock()
+	factories[name] = f
+	factoriesMu.Unlock()
+}
+
+// New returns an empty tick of the type registered with the name, ok is false if the type is unknown.
+func New(name string) (Tick, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	if f, ok := factories[name]; ok {
+		return f(), true
+	}
+
+	return nil, false
+}
+
+func init() {
+	factories = map[string]Factory{
+		KoTHType: func() Tick {
+			return &KoTHTick{}
+		},
+		SpawnType: func() Tick {
+			return NewZoneTick(SpawnType)
+		},
+		RoadType: func() Tick {
+			return NewZoneTick(RoadType)
+		},
+	}
+}
@@ -2,4 +2,11 @@ package tickable
 
 type Tick interface {
 	DoTick()
+
+	// Type returns the name the tick type is registered with
+	Type() string
+	// Marshal returns the tick's state as a map
+	Marshal() (map[string]interface{}, error)
+	// Unmarshal loads the tick's state from a map
+	Unmarshal(body map[string]interface{}) error
 }
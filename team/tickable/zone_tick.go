@@ -0,0 +1,29 @@
+package tickable
+
+// ZoneTick is the tick of system teams that only protect an area, like spawn or roads.
+// It does not do anything on each tick and it has no state to store.
+type ZoneTick struct {
+	kind string
+}
+
+// NewZoneTick returns a new zone tick registered with the type name passed.
+func NewZoneTick(kind string) *ZoneTick {
+	return &ZoneTick{kind: kind}
+}
+
+func (*ZoneTick) DoTick() {}
+
+// Type returns the name the tick type is registered with.
+func (zt *ZoneTick) Type() string {
+	return zt.kind
+}
+
+// Marshal returns an empty map, the zone tick has no state.
+func (*ZoneTick) Marshal() (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+// Unmarshal does nothing, the zone tick has no state.
+func (*ZoneTick) Unmarshal(map[string]interface{}) error {
+	return nil
+}
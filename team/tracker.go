@@ -2,15 +2,20 @@ package team
 
 import (
     "errors"
+    "fmt"
     "github.com/df-mc/dragonfly/server/block/cube"
     "github.com/df-mc/dragonfly/server/world"
     "github.com/go-gl/mathgl/mgl64"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "slices"
+    "sync"
     "sync/atomic"
 )
 
 var (
     BlockBreakableKeyOption = "block_breakable"
     BlockPlaceableKeyOption = "block_placeable"
+    InteractableKeyOption   = "interactable"
     FriendlyFireKeyOption   = "friendly_fire"
     DisplayNameKeyOption    = "display_name"
     SafeZoneKeyOption       = "safe_zone"
@@ -24,9 +29,11 @@ type Tracker struct {
     balance atomic.Int32
     points  atomic.Int32
 
-    options map[string]interface{}
+    optionsMu sync.RWMutex           // Protects options
+    options   map[string]interface{} // Option key -> Value
 
-    cuboids map[string][]cube.BBox
+    cuboidsMu sync.RWMutex             // Protects cuboids
+    cuboids   map[WorldKey][]cube.BBox // World -> Cuboids
 }
 
 // Id returns the team's ID
@@ -49,23 +56,111 @@ func (t *Tracker) Balance() int32 {
     return t.balance.Load()
 }
 
+// SetBalance sets the team's balance
+func (t *Tracker) SetBalance(balance int32) {
+    t.balance.Store(balance)
+}
+
+// Withdraw takes the given amount away from the team's balance, returns false and takes nothing
+// if the balance is not enough
+func (t *Tracker) Withdraw(amount int32) bool {
+    for {
+        balance := t.balance.Load()
+        if balance < amount {
+            return false
+        }
+
+        if t.balance.CompareAndSwap(balance, balance-amount) {
+            return true
+        }
+    }
+}
+
+// AddBalance adds the given amount to the team's balance, a negative amount takes it away
+func (t *Tracker) AddBalance(amount int32) int32 {
+    return t.balance.Add(amount)
+}
+
 // Points returns the team's points
 func (t *Tracker) Points() int32 {
     return t.points.Load()
 }
 
+// AddPoints adds the given amount to the team's points, a negative amount takes them away
+func (t *Tracker) AddPoints(amount int32) int32 {
+    return t.points.Add(amount)
+}
+
 // Option returns the team's option
 func (t *Tracker) Option(key string) interface{} {
+    t.optionsMu.RLock()
+    defer t.optionsMu.RUnlock()
+
     return t.options[key]
 }
 
+// SetOption sets the team's option
+func (t *Tracker) SetOption(key string, value interface{}) {
+    t.optionsMu.Lock()
+    defer t.optionsMu.Unlock()
+
+    if t.options == nil {
+        t.options = make(map[string]interface{})
+    }
+
+    t.options[key] = value
+}
+
 // Cuboids returns the team's cuboids
-func (t *Tracker) Cuboids() map[string][]cube.BBox {
-    return t.cuboids
+func (t *Tracker) Cuboids() map[WorldKey][]cube.BBox {
+    t.cuboidsMu.RLock()
+    defer t.cuboidsMu.RUnlock()
+
+    // The cuboids are copied, so the caller can iterate them without holding the lock
+    cuboids := make(map[WorldKey][]cube.BBox, len(t.cuboids))
+    for key, bBoxes := range t.cuboids {
+        cuboids[key] = slices.Clone(bBoxes)
+    }
+
+    return cuboids
+}
+
+// AddCuboid adds a cuboid to the team's land in the given world
+func (t *Tracker) AddCuboid(key WorldKey, bbox cube.BBox) {
+    t.cuboidsMu.Lock()
+    defer t.cuboidsMu.Unlock()
+
+    if t.cuboids == nil {
+        t.cuboids = make(map[WorldKey][]cube.BBox)
+    }
+
+    t.cuboids[key] = append(t.cuboids[key], bbox)
+}
+
+// RemoveCuboid removes a cuboid from the team's land in the given world
+func (t *Tracker) RemoveCuboid(key WorldKey, bbox cube.BBox) bool {
+    t.cuboidsMu.Lock()
+    defer t.cuboidsMu.Unlock()
+
+    if i := slices.Index(t.cuboids[key], bbox); i != -1 {
+        t.cuboids[key] = slices.Delete(t.cuboids[key], i, i+1)
+
+        if len(t.cuboids[key]) == 0 {
+            delete(t.cuboids, key)
+        }
+
+        return true
+    }
+
+    return false
 }
 
+// Inside returns true if the Vec3 is within any of the team's cuboids in the given world
 func (t *Tracker) Inside(w *world.World, vec mgl64.Vec3) bool {
-    for _, c := range t.cuboids[w.Name()] {
+    t.cuboidsMu.RLock()
+    defer t.cuboidsMu.RUnlock()
+
+    for _, c := range t.cuboids[WorldKeyOf(w)] {
         if c.Vec3Within(vec) {
             return true
         }
@@ -76,9 +171,36 @@ func (t *Tracker) Inside(w *world.World, vec mgl64.Vec3) bool {
 
 // Marshal handles the serialization of the tracker struct
 func (t *Tracker) Marshal() map[string]interface{} {
+    t.optionsMu.RLock()
+    options := make(map[string]interface{}, len(t.options))
+    for k, v := range t.options {
+        options[k] = v
+    }
+    t.optionsMu.RUnlock()
+
+    // Wrap the cuboids in a map of world names to the dimension and the min and max corners,
+    // the default worlds share their name so the dimension is stored with every cuboid
+    cuboids := make(map[string][]map[string]interface{})
+    for key, bBoxes := range t.Cuboids() {
+        dim, _ := world.DimensionID(key.Dim)
+
+        for _, bbox := range bBoxes {
+            cuboids[key.Name] = append(cuboids[key.Name], map[string]interface{}{
+                "dimension": dim,
+                "min":       marshalVec3(bbox.Min()),
+                "max":       marshalVec3(bbox.Max()),
+            })
+        }
+    }
+
     return map[string]interface{}{
-        "id":   t.id,
-        "name": t.name,
+        "id":      t.id,
+        "name":    t.name,
+        "type":    t.teamType,
+        "balance": t.balance.Load(),
+        "points":  t.points.Load(),
+        "options": options,
+        "cuboids": cuboids,
     }
 }
 
@@ -96,5 +218,70 @@ func (t *Tracker) Unmarshal(body map[string]interface{}) error {
     }
     t.name = name
 
+    teamType, ok := body["type"].(string)
+    if !ok {
+        return errors.New("missing type")
+    }
+    t.teamType = teamType
+
+    balance, ok := body["balance"].(int32)
+    if !ok {
+        return errors.New("missing balance")
+    }
+    t.balance.Store(balance)
+
+    points, ok := body["points"].(int32)
+    if !ok {
+        return errors.New("missing points")
+    }
+    t.points.Store(points)
+
+    t.options = make(map[string]interface{})
+    if options, ok := body["options"].(map[string]interface{}); ok {
+        for k, v := range options {
+            t.options[k] = v
+        }
+    }
+
+    t.cuboids = make(map[WorldKey][]cube.BBox)
+    if cuboids, ok := body["cuboids"].(map[string]interface{}); ok {
+        for wName, v := range cuboids {
+            bBoxes, ok := v.(primitive.A)
+            if !ok {
+                return errors.New("cuboids of world '" + wName + "' are not an array")
+            }
+
+            for _, bboxBody := range bBoxes {
+                bboxProp, ok := bboxBody.(map[string]interface{})
+                if !ok {
+                    return errors.New("cuboid of world '" + wName + "' is not a map")
+                }
+
+                minVec, err := unmarshalVec3(bboxProp["min"])
+                if err != nil {
+                    return errors.Join(errors.New("invalid cuboid min of world '"+wName+"': "), err)
+                }
+
+                maxVec, err := unmarshalVec3(bboxProp["max"])
+                if err != nil {
+                    return errors.Join(errors.New("invalid cuboid max of world '"+wName+"': "), err)
+                }
+
+                // Cuboids saved before the dimension was stored are in the overworld
+                key := WorldKey{Name: wName, Dim: world.Overworld}
+                if id, ok := bboxProp["dimension"].(int32); ok {
+                    dim, ok := world.DimensionByID(int(id))
+                    if !ok {
+                        return fmt.Errorf("unknown dimension %d of a cuboid of world '%s'", id, wName)
+                    }
+
+                    key.Dim = dim
+                }
+
+                t.cuboids[key] = append(t.cuboids[key], cube.Box(minVec[0], minVec[1], minVec[2], maxVec[0], maxVec[1], maxVec[2]))
+            }
+        }
+    }
+
     return nil
 }
@@ -0,0 +1,145 @@
+package team
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The default worlds of the server share their name
+var (
+	overworld = WorldKey{Name: "World", Dim: world.Overworld}
+	nether    = WorldKey{Name: "World", Dim: world.Nether}
+)
+
+func TestTrackerCuboidsCopy(t *testing.T) {
+	tracker := &Tracker{}
+	tracker.AddCuboid(overworld, cube.Box(0, 0, 0, 16, 256, 16))
+
+	cuboids := tracker.Cuboids()
+	cuboids[overworld][0] = cube.Box(100, 0, 100, 116, 256, 116)
+	cuboids[nether] = []cube.BBox{cube.Box(0, 0, 0, 1, 1, 1)}
+
+	got := tracker.Cuboids()
+	if len(got) != 1 || got[overworld][0] != cube.Box(0, 0, 0, 16, 256, 16) {
+		t.Fatalf("changing the returned cuboids changed the tracker: %v", got)
+	}
+}
+
+func TestTrackerRemoveCuboid(t *testing.T) {
+	tracker := &Tracker{}
+	first, second := cube.Box(0, 0, 0, 16, 256, 16), cube.Box(16, 0, 0, 32, 256, 16)
+	tracker.AddCuboid(overworld, first)
+	tracker.AddCuboid(overworld, second)
+
+	if tracker.RemoveCuboid(overworld, cube.Box(64, 0, 64, 80, 256, 80)) {
+		t.Fatal("removed a cuboid the team does not own")
+	}
+
+	if !tracker.RemoveCuboid(overworld, first) {
+		t.Fatal("did not remove an owned cuboid")
+	}
+
+	if got := tracker.Cuboids()[overworld]; len(got) != 1 || got[0] != second {
+		t.Fatalf("expected only the second cuboid to remain, got %v", got)
+	}
+
+	tracker.RemoveCuboid(overworld, second)
+	if _, ok := tracker.Cuboids()[overworld]; ok {
+		t.Fatal("the world was kept after removing its last cuboid")
+	}
+}
+
+func TestTrackerCuboidsPerDimension(t *testing.T) {
+	tracker := &Tracker{}
+	bbox := cube.Box(0, 0, 0, 16, 256, 16)
+	tracker.AddCuboid(overworld, bbox)
+
+	if _, ok := tracker.Cuboids()[nether]; ok {
+		t.Fatal("a cuboid of the overworld is also in the nether")
+	}
+
+	if tracker.RemoveCuboid(nether, bbox) {
+		t.Fatal("removed a cuboid of the overworld from the nether")
+	}
+}
+
+func TestTrackerWithdraw(t *testing.T) {
+	tracker := &Tracker{}
+	tracker.SetBalance(100)
+
+	if !tracker.Withdraw(60) || tracker.Balance() != 40 {
+		t.Fatalf("expected a balance of 40 after withdrawing 60, got %d", tracker.Balance())
+	}
+
+	if tracker.Withdraw(50) || tracker.Balance() != 40 {
+		t.Fatalf("withdrew more than the balance, got %d", tracker.Balance())
+	}
+}
+
+func TestTrackerRoundTrip(t *testing.T) {
+	tracker := &Tracker{id: "id", name: "Spawn", teamType: SystemTeamType}
+	tracker.SetBalance(250)
+	tracker.AddPoints(30)
+	tracker.SetOption(SafeZoneKeyOption, true)
+	tracker.SetOption(DisplayNameKeyOption, "The Spawn")
+	tracker.AddCuboid(overworld, cube.Box(-16, 0, -16, 16, 256, 16))
+	tracker.AddCuboid(overworld, cube.Box(100, 0, 100, 120, 256, 120))
+	tracker.AddCuboid(nether, cube.Box(0, 0, 0, 8, 128, 8))
+
+	got := &Tracker{}
+	if err := got.Unmarshal(roundTrip(t, tracker.Marshal())); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if got.Id() != "id" || got.Name() != "Spawn" || got.TeamType() != SystemTeamType {
+		t.Fatalf("identity mismatch: %s %s %s", got.Id(), got.Name(), got.TeamType())
+	}
+
+	if got.Balance() != 250 || got.Points() != 30 {
+		t.Fatalf("expected a balance of 250 and 30 points, got %d and %d", got.Balance(), got.Points())
+	}
+
+	if got.Option(SafeZoneKeyOption) != true || got.Option(DisplayNameKeyOption) != "The Spawn" {
+		t.Fatalf("options mismatch: %v %v", got.Option(SafeZoneKeyOption), got.Option(DisplayNameKeyOption))
+	}
+
+	if !reflect.DeepEqual(got.Cuboids(), tracker.Cuboids()) {
+		t.Fatalf("expected cuboids %v, got %v", tracker.Cuboids(), got.Cuboids())
+	}
+}
+
+func TestTrackerUnmarshalMissingFields(t *testing.T) {
+	body := (&Tracker{id: "id", name: "Alpha", teamType: PlayerTeamType}).Marshal()
+
+	for _, key := range []string{"id", "name", "type", "balance", "points"} {
+		decoded := roundTrip(t, body)
+		delete(decoded, key)
+
+		if err := (&Tracker{}).Unmarshal(decoded); err == nil {
+			t.Errorf("expected an error without %s", key)
+		}
+	}
+}
+
+func TestTrackerUnmarshalWithoutDimension(t *testing.T) {
+	tracker := &Tracker{id: "id", name: "Alpha", teamType: PlayerTeamType}
+	tracker.AddCuboid(nether, cube.Box(0, 0, 0, 16, 128, 16))
+
+	body := roundTrip(t, tracker.Marshal())
+	for _, bbox := range body["cuboids"].(map[string]interface{})["World"].(primitive.A) {
+		delete(bbox.(map[string]interface{}), "dimension")
+	}
+
+	got := &Tracker{}
+	if err := got.Unmarshal(body); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if len(got.Cuboids()[overworld]) != 1 {
+		t.Fatalf("expected the cuboids saved without a dimension in the overworld, got %v", got.Cuboids())
+	}
+}
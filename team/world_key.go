@@ -0,0 +1,15 @@
+package team
+
+import "github.com/df-mc/dragonfly/server/world"
+
+// WorldKey identifies a world by its name and dimension, because the default worlds of the server
+// share the same provider and therefore the same name.
+type WorldKey struct {
+	Name string
+	Dim  world.Dimension
+}
+
+// WorldKeyOf returns the key of the world.
+func WorldKeyOf(w *world.World) WorldKey {
+	return WorldKey{Name: w.Name(), Dim: w.Dimension()}
+}
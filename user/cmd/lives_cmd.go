@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"strconv"
+)
+
+type LivesCmd struct{}
+
+func (LivesCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else {
+		output.Print(message.SuccessSelfLives.Build(strconv.Itoa(int(u.Lives()))))
+	}
+}
+
+type LivesUseCmd struct {
+	Sub cmd.SubCommand `cmd:"use"`
+}
+
+func (LivesUseCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if !u.Deathbanned() {
+		output.Error(message.ErrSelfNotDeathbanned.Build())
+	} else if lives, ok := spendLife(u); !ok {
+		output.Error(message.ErrNoLives.Build())
+	} else if !u.ClearDeathban() {
+		// The deathban ended while the life was spent
+		u.AddLives(1)
+
+		output.Error(message.ErrSelfNotDeathbanned.Build())
+	} else {
+		output.Print(message.SuccessSelfRevived.Build(strconv.Itoa(int(lives))))
+
+		service.User().SaveAsync(u)
+	}
+}
+
+type LivesGiveCmd struct {
+	Sub    cmd.SubCommand `cmd:"give"`
+	Target string         `cmd:"player"`
+	Amount int            `cmd:"amount"`
+}
+
+func (c LivesGiveCmd) Run(_ cmd.Source, output *cmd.Output) {
+	if c.Amount <= 0 {
+		output.Error(message.ErrLivesInvalidAmount.Build())
+	} else if u := service.User().LookupByName(c.Target); u == nil {
+		output.Error(message.ErrPlayerNotFound.Build(c.Target))
+	} else {
+		u.AddLives(int32(c.Amount))
+
+		output.Print(message.SuccessLivesGiven.Build(strconv.Itoa(c.Amount), u.Name()))
+
+		service.User().SaveAsync(u)
+	}
+}
+
+// Allow only lets the operators give lives.
+func (LivesGiveCmd) Allow(src cmd.Source) bool {
+	return service.User().Operator(src)
+}
+
+type ReviveCmd struct {
+	Target string `cmd:"player"`
+}
+
+func (c ReviveCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if target := service.User().LookupByName(c.Target); target == nil {
+		output.Error(message.ErrPlayerNotFound.Build(c.Target))
+	} else if !target.Deathbanned() {
+		output.Error(message.ErrPlayerNotDeathbanned.Build(target.Name()))
+	} else if lives, ok := spendLife(u); !ok {
+		output.Error(message.ErrNoLives.Build())
+	} else if !target.ClearDeathban() {
+		// Someone else revived the target or the deathban ended while the life was spent
+		u.AddLives(1)
+
+		output.Error(message.ErrPlayerNotDeathbanned.Build(target.Name()))
+	} else {
+		output.Print(message.SuccessPlayerRevived.Build(target.Name(), strconv.Itoa(int(lives))))
+
+		service.User().SaveAsync(u)
+		service.User().SaveAsync(target)
+	}
+}
+
+// spendLife takes a life away from the user and returns the lives left, ok is false if the user had none.
+// The lives are taken away before checking them, so two commands cannot spend the same last life.
+func spendLife(u *user.User) (int32, bool) {
+	if lives := u.AddLives(-1); lives >= 0 {
+		return lives, true
+	}
+
+	u.AddLives(1)
+
+	return 0, false
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"time"
+)
+
+type LogoutCmd struct{}
+
+func (LogoutCmd) Run(src cmd.Source, output *cmd.Output) {
+	logoutTime := time.Duration(config.CombatConfig().LogoutTime) * time.Second
+
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if !u.Logout().Start(logoutTime, func() {
+		u.SetSafeLogout(true)
+
+		p.Disconnect(message.SuccessSelfLoggedOut.Build())
+	}) {
+		output.Error(message.ErrLogoutAlreadyRunning.Build())
+	} else {
+		output.Print(message.SuccessSelfLogoutStarted.Build(logoutTime.String()))
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+type PvPEnableCmd struct {
+	Sub cmd.SubCommand `cmd:"enable"`
+}
+
+func (PvPEnableCmd) Run(src cmd.Source, output *cmd.Output) {
+	if p, ok := src.(*player.Player); !ok {
+		output.Error(text.Red + "This command can only be run by a player.")
+	} else if u := service.User().LookupByXUID(p.XUID()); u == nil {
+		output.Error(text.DarkRed + "An error occurred while checking your user.")
+	} else if !u.PvPProtected() {
+		output.Error(message.ErrPvPNotProtected.Build())
+	} else {
+		u.SetPvPTimer(0)
+
+		output.Print(message.SuccessSelfPvPEnabled.Build())
+
+		service.User().SaveAsync(u)
+	}
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"sync"
+	"time"
+)
+
+// Countdown runs an action once its duration passes, unless it is cancelled before.
+// It is used by the actions that make the user wait, like logging out or teleporting home.
+type Countdown struct {
+	mu sync.Mutex // Protects the fields below
+
+	timer *time.Timer
+	until time.Time
+}
+
+// Start starts the countdown, calling f once the duration passes.
+// Returns false if the countdown was already running.
+func (c *Countdown) Start(d time.Duration, f func()) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer != nil {
+		return false
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		c.mu.Lock()
+		// The countdown was cancelled or restarted while the action was about to run
+		if c.timer != timer {
+			c.mu.Unlock()
+
+			return
+		}
+
+		c.timer = nil
+		c.until = time.Time{}
+		c.mu.Unlock()
+
+		f()
+	})
+
+	c.timer = timer
+	c.until = time.Now().Add(d)
+
+	return true
+}
+
+// Cancel stops the countdown without calling its action.
+// Returns false if the countdown was not running.
+func (c *Countdown) Cancel() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer == nil {
+		return false
+	}
+
+	c.timer.Stop()
+
+	c.timer = nil
+	c.until = time.Time{}
+
+	return true
+}
+
+// Active returns true if the countdown is running.
+func (c *Countdown) Active() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.timer != nil
+}
+
+// Remaining returns the remaining time until the action of the countdown runs.
+func (c *Countdown) Remaining() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer == nil {
+		return 0
+	}
+
+	return max(time.Until(c.until), 0)
+}
@@ -0,0 +1,82 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountdownRuns(t *testing.T) {
+	var c Countdown
+	done := make(chan struct{})
+
+	if !c.Start(10*time.Millisecond, func() { close(done) }) {
+		t.Fatal("did not start an idle countdown")
+	}
+
+	if !c.Active() || c.Remaining() <= 0 {
+		t.Fatal("the countdown is not running after starting it")
+	}
+
+	if c.Start(time.Millisecond, func() { t.Error("ran the action of a second start") }) {
+		t.Fatal("started a countdown that was already running")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the action did not run")
+	}
+
+	if c.Active() || c.Remaining() != 0 {
+		t.Fatal("the countdown is still running after its action")
+	}
+}
+
+func TestCountdownCancel(t *testing.T) {
+	var c Countdown
+	ran := make(chan struct{}, 1)
+
+	if c.Cancel() {
+		t.Fatal("cancelled an idle countdown")
+	}
+
+	c.Start(20*time.Millisecond, func() { ran <- struct{}{} })
+
+	if !c.Cancel() {
+		t.Fatal("did not cancel a running countdown")
+	}
+
+	if c.Active() || c.Remaining() != 0 {
+		t.Fatal("the countdown is still running after cancelling it")
+	}
+
+	select {
+	case <-ran:
+		t.Fatal("the action ran after cancelling the countdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCountdownRestart(t *testing.T) {
+	var c Countdown
+	first, second := make(chan struct{}, 1), make(chan struct{}, 1)
+
+	c.Start(20*time.Millisecond, func() { first <- struct{}{} })
+	c.Cancel()
+
+	if !c.Start(30*time.Millisecond, func() { second <- struct{}{} }) {
+		t.Fatal("did not start the countdown after cancelling it")
+	}
+
+	select {
+	case <-second:
+	case <-time.After(time.Second):
+		t.Fatal("the action of the restarted countdown did not run")
+	}
+
+	select {
+	case <-first:
+		t.Fatal("the action of the cancelled countdown ran")
+	default:
+	}
+}
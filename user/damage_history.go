@@ -0,0 +1,98 @@
+package user
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxDamageRecords is the most records kept by a damage history, the oldest are discarded first.
+const maxDamageRecords = 32
+
+// DamageRecord is a hit another player dealt to the user.
+type DamageRecord struct {
+	Attacker string     // XUID of the attacker
+	Damage   float64    // Damage dealt before armour reductions
+	Weapon   item.Stack // Item held by the attacker
+	At       time.Time
+}
+
+// DamageHistory holds the recent hits other players dealt to the user, so the killer and the assisting
+// players can be worked out when the user dies.
+type DamageHistory struct {
+	mu      sync.Mutex // Protects records
+	records []DamageRecord
+}
+
+// Record adds a hit dealt by the attacker to the history.
+func (h *DamageHistory) Record(attacker string, damage float64, weapon item.Stack) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, DamageRecord{
+		Attacker: attacker,
+		Damage:   damage,
+		Weapon:   weapon,
+		At:       time.Now(),
+	})
+
+	if len(h.records) > maxDamageRecords {
+		h.records = slices.Delete(h.records, 0, len(h.records)-maxDamageRecords)
+	}
+}
+
+// Killer returns the last hit dealt within the window, ok is false if nobody hit the user within it.
+func (h *DamageHistory) Killer(window time.Duration) (DamageRecord, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.records) == 0 {
+		return DamageRecord{}, false
+	}
+
+	last := h.records[len(h.records)-1]
+	if time.Since(last.At) > window {
+		return DamageRecord{}, false
+	}
+
+	return last, true
+}
+
+// Assists returns the XUIDs of the players who hit the user within the window, excluding the killer,
+// sorted by the damage they dealt, the highest first.
+func (h *DamageHistory) Assists(window time.Duration, killer string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	damage := make(map[string]float64)
+	for _, r := range h.records {
+		if r.Attacker != killer && time.Since(r.At) <= window {
+			damage[r.Attacker] += r.Damage
+		}
+	}
+
+	assists := make([]string, 0, len(damage))
+	for xuid := range damage {
+		assists = append(assists, xuid)
+	}
+
+	slices.SortFunc(assists, func(a, b string) int {
+		if damage[a] > damage[b] {
+			return -1
+		} else if damage[a] < damage[b] {
+			return 1
+		}
+
+		return 0
+	})
+
+	return assists
+}
+
+// Clear removes every record from the history.
+func (h *DamageHistory) Clear() {
+	h.mu.Lock()
+	h.records = nil
+	h.mu.Unlock()
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"time"
+)
+
+type combatHandler struct{}
+
+// RegisterCombatHandler registers the handler that tags players in combat and restricts them while tagged.
+// It must be registered after the safe zone and friendly fire handlers, so cancelled hits do not tag anyone,
+// and before the move handler, so the territory is not updated when entering a safe zone is denied.
+func RegisterCombatHandler() {
+	handler.RegisterHandler(handler.HurtHandlerID, combatHandler{})
+	handler.RegisterHandler(handler.MoveHandlerID, combatHandler{})
+	handler.RegisterHandler(handler.CommandExecutionHandlerID, combatHandler{})
+}
+
+// HandleHurt tags the victim and the attacker in combat.
+func (combatHandler) HandleHurt(p *player.Player, ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if attacker, ok := service.AttackerOf(src); ok && attacker != p {
+		combatTag(p)
+		combatTag(attacker)
+	}
+}
+
+// HandleMove prevents players in combat from entering a safe zone.
+func (combatHandler) HandleMove(p *player.Player, ctx *event.Context, newPos mgl64.Vec3, _, _ float64) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil || !u.CombatTagged() {
+		return
+	}
+
+	if service.Team().SafeZoneAt(p.World(), newPos) && !service.Team().SafeZoneAt(p.World(), p.Position()) {
+		ctx.Cancel()
+
+		p.Message(message.ErrCombatSafeZone.Build(u.CombatTagRemaining().Round(time.Second).String()))
+	}
+}
+
+// HandleCommandExecution prevents players in combat from using the blocked commands.
+func (combatHandler) HandleCommandExecution(p *player.Player, ctx *event.Context, command cmd.Command, args []string) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil || !u.CombatTagged() || !combatBlocked(command, args) {
+		return
+	}
+
+	ctx.Cancel()
+
+	p.Message(message.ErrCombatBlockedCommand.Build(u.CombatTagRemaining().Round(time.Second).String()))
+}
+
+// combatTag tags the player in combat, notifying them if they were not already tagged.
+func combatTag(p *player.Player) {
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil {
+		return
+	}
+
+	tagTime := time.Duration(config.CombatConfig().TagTime) * time.Second
+	if !u.CombatTagged() {
+		p.Message(message.SuccessSelfCombatTagged.Build(tagTime.String()))
+	}
+
+	u.SetCombatTag(tagTime)
+}
+
+// combatBlocked returns true if the command line, using the command name or any of its aliases,
+// starts with any of the commands blocked while in combat.
+func combatBlocked(command cmd.Command, args []string) bool {
+	for _, name := range append([]string{command.Name()}, command.Aliases()...) {
+		line := strings.ToLower(strings.Join(append([]string{name}, args...), " "))
+
+		for _, blocked := range config.CombatConfig().BlockedCommands {
+			blocked = strings.ToLower(blocked)
+			if line == blocked || strings.HasPrefix(line, blocked+" ") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+type countdownHandler struct{}
+
+// RegisterCountdownHandler registers the handler that cancels the countdowns of the user when they move or take damage.
+func RegisterCountdownHandler() {
+	handler.RegisterHandler(handler.HurtHandlerID, countdownHandler{})
+	handler.RegisterHandler(handler.MoveHandlerID, countdownHandler{})
+}
+
+// HandleHurt cancels the countdowns when the player takes damage.
+func (countdownHandler) HandleHurt(p *player.Player, ctx *event.Context, _ *float64, _ *time.Duration, _ world.DamageSource) {
+	if !ctx.Cancelled() {
+		cancelCountdowns(p)
+	}
+}
+
+// HandleMove cancels the countdowns when the player moves to another block, looking around is allowed.
+func (countdownHandler) HandleMove(p *player.Player, ctx *event.Context, newPos mgl64.Vec3, _, _ float64) {
+	if !ctx.Cancelled() && cube.PosFromVec3(newPos) != cube.PosFromVec3(p.Position()) {
+		cancelCountdowns(p)
+	}
+}
+
+// cancelCountdowns cancels the running countdowns of the player and notifies them.
+func cancelCountdowns(p *player.Player) {
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil {
+		return
+	}
+
+	if u.Home().Cancel() {
+		p.Message(message.ErrTeamHomeCancelled.Build())
+	}
+
+	if u.Logout().Cancel() {
+		p.Message(message.ErrLogoutCancelled.Build())
+	}
+}
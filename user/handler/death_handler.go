@@ -3,8 +3,16 @@ package handler
 import (
 	"github.com/aabstractt/aurial/handler"
 	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
 	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/bitrule/disrupt/user"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type deathHandler struct{}
@@ -21,8 +29,93 @@ func (deathHandler) HandleDeath(p *player.Player) {
 		return
 	}
 
-	t := service.Team().LookupByMember(p.XUID())
-	if t != nil {
-		t.DTR().UpdateRemaining(120) // Freezes DTR for 120 seconds
+	u.ClearCombatTag()
+
+	window := time.Duration(config.CombatConfig().DamageWindow) * time.Second
+	killer, hasKiller := u.DamageHistory().Killer(window)
+	assists := u.DamageHistory().Assists(window, killer.Attacker)
+	u.DamageHistory().Clear()
+
+	u.Tracker().IncDeaths()
+	u.Tracker().ResetStreak()
+
+	var ku *user.User
+	if hasKiller {
+		ku = service.User().LookupByXUID(killer.Attacker)
+	}
+
+	if ku != nil {
+		service.User().AddKill(ku)
+
+		service.User().SaveAsync(ku)
+
+		service.Broadcast(message.BroadcastPlayerKilled.Build(
+			u.Name(),
+			strconv.FormatInt(u.Tracker().Kills(), 10),
+			ku.Name(),
+			strconv.FormatInt(ku.Tracker().Kills(), 10),
+			weaponName(killer.Weapon),
+		))
+	} else {
+		service.Broadcast(message.BroadcastPlayerDied.Build(u.Name(), strconv.FormatInt(u.Tracker().Kills(), 10)))
+	}
+
+	for _, xuid := range assists {
+		if au := service.User().LookupByXUID(xuid); au != nil {
+			au.Tracker().IncAssists()
+
+			service.User().SaveAsync(au)
+		}
+	}
+
+	// The deathban saves the user, including the death
+	deathban(p, u)
+
+	if t := service.Team().LookupByMember(p.XUID()); t != nil {
+		var killerTeam *team.PlayerTeam
+		if ku != nil {
+			killerTeam = service.Team().LookupByMember(ku.XUID())
+		}
+
+		applyDeathPenalty(p, t, killerTeam)
 	}
 }
+
+// applyDeathPenalty takes DTR away from the team of the player and, if the death made the team raidable,
+// gives the raid to the team of the killer.
+func applyDeathPenalty(p *player.Player, t, killerTeam *team.PlayerTeam) {
+	wasRaidable := t.DTR().Raidable()
+	service.Team().ApplyDeathPenalty(t, p.Name(), p.World(), p.Position())
+
+	if !wasRaidable && t.DTR().Raidable() && killerTeam != nil && killerTeam != t {
+		service.Team().Raid(t, killerTeam)
+	}
+}
+
+// deathban bans the player from the map and kicks them after the configured delay,
+// which gives them time to use a life or be revived by a friend.
+func deathban(p *player.Player, u *user.User) {
+	service.User().Deathban(u)
+
+	kickDelay := time.Duration(config.DeathbanConfig().KickDelay) * time.Second
+	p.Message(message.ErrSelfDeathbanned.Build(u.DeathbanRemaining().Round(time.Second).String(), kickDelay.String()))
+
+	time.AfterFunc(kickDelay, func() {
+		if u.Deathbanned() {
+			p.Disconnect(message.ErrDeathbanKick.Build(u.DeathbanRemaining().Round(time.Second).String()))
+		}
+	})
+}
+
+// weaponName returns the name of the weapon shown in the death messages.
+func weaponName(weapon item.Stack) string {
+	if weapon.Empty() {
+		return message.DeathWeaponFists.Build()
+	} else if name := weapon.CustomName(); name != "" {
+		return name
+	}
+
+	name, _ := weapon.Item().EncodeItem()
+
+	return strings.ReplaceAll(strings.TrimPrefix(name, "minecraft:"), "_", " ")
+}
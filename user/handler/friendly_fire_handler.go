@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+type friendlyFireHandler struct{}
+
+// RegisterFriendlyFireHandler registers the handler that prevents team members and allies from hurting each other.
+// It must be registered before any other hurt handler, so they see the damage cancelled.
+func RegisterFriendlyFireHandler() {
+	handler.RegisterHandler(handler.AttackEntityHandlerID, friendlyFireHandler{})
+	handler.RegisterHandler(handler.HurtHandlerID, friendlyFireHandler{})
+}
+
+// HandleAttackEntity cancels melee hits between team members and allies.
+func (friendlyFireHandler) HandleAttackEntity(p *player.Player, ctx *event.Context, e world.Entity, _, _ *float64, _ *bool) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if target, ok := e.(*player.Player); ok && !service.Team().CanDamage(p.XUID(), target.XUID()) {
+		ctx.Cancel()
+
+		p.Message(message.ErrFriendlyFire.Build(target.Name()))
+	}
+}
+
+// HandleHurt cancels projectile damage between team members and allies, melee hits are already
+// cancelled by HandleAttackEntity.
+func (friendlyFireHandler) HandleHurt(p *player.Player, ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if _, ok := src.(entity.ProjectileDamageSource); !ok {
+		return
+	}
+
+	if attacker, ok := service.AttackerOf(src); ok && attacker != p && !service.Team().CanDamage(attacker.XUID(), p.XUID()) {
+		ctx.Cancel()
+
+		attacker.Message(message.ErrFriendlyFire.Build(p.Name()))
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+type hurtHandler struct{}
+
+func RegisterHurtHandler() {
+	handler.RegisterHandler(handler.HurtHandlerID, hurtHandler{})
+}
+
+// HandleHurt records the hit into the damage history of the victim.
+func (hurtHandler) HandleHurt(p *player.Player, ctx *event.Context, damage *float64, _ *time.Duration, src world.DamageSource) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	attacker, ok := service.AttackerOf(src)
+	if !ok || attacker == p {
+		return
+	}
+
+	if u := service.User().LookupByXUID(p.XUID()); u != nil {
+		weapon, _ := attacker.HeldItems()
+
+		u.DamageHistory().Record(attacker.XUID(), *damage, weapon)
+	}
+}
@@ -1,20 +1,29 @@
 package handler
 
 import (
+	"github.com/aabstractt/aurial/handler"
 	"github.com/bitrule/disrupt"
+	"github.com/bitrule/disrupt/message"
 	"github.com/bitrule/disrupt/service"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/sandertv/gophertunnel/minecraft/text"
+	"time"
 )
 
 type userJoinHandler struct{}
 
 func RegisterJoinHandler() {
-	// TODO: Register with aurial
+	handler.RegisterHandler(handler.JoinHandlerID, userJoinHandler{})
 }
 
 func (userJoinHandler) HandleJoin(p *player.Player) {
-	if service.User().LookupByXUID(p.XUID()) == nil {
+	if u := service.User().LookupByXUID(p.XUID()); u != nil && u.Deathbanned() {
+		p.Disconnect(message.ErrDeathbanKick.Build(u.DeathbanRemaining().Round(time.Second).String()))
+	} else if u != nil {
+		u.SetLastSeen(time.Now())
+
+		service.CombatLogger().Rejoin(p, u)
+	} else {
 		go func() {
 			if err := service.User().Create(p.XUID(), p.Name()); err != nil {
 				p.Disconnect(text.Red + "An error occurred while creating your user.\n" + text.Yellow + "Please try again later.")
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+type moveHandler struct{}
+
+func RegisterMoveHandler() {
+	handler.RegisterHandler(handler.MoveHandlerID, moveHandler{})
+}
+
+// HandleMove keeps track of the territory the player is in and notifies them when it changes.
+func (moveHandler) HandleMove(p *player.Player, ctx *event.Context, newPos mgl64.Vec3, _, _ float64) {
+	if ctx.Cancelled() || cube.PosFromVec3(newPos) == cube.PosFromVec3(p.Position()) {
+		return
+	}
+
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil {
+		return
+	}
+
+	from, to := u.TeamAt(), service.Team().TerritoryAt(p.World(), newPos)
+	if from == to {
+		return
+	}
+
+	u.SetTeamAt(to)
+
+	// The first movement after joining only sets the territory
+	if from == "" {
+		return
+	}
+
+	p.Message(message.SuccessSelfTerritoryLeaving.Build(service.Team().TerritoryName(p, from)))
+	p.Message(message.SuccessSelfTerritoryEntering.Build(service.Team().TerritoryName(p, to)))
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+type protectionHandler struct{}
+
+func RegisterProtectionHandler() {
+	handler.RegisterHandler(handler.BlockBreakHandlerID, protectionHandler{})
+	handler.RegisterHandler(handler.BlockPlaceHandlerID, protectionHandler{})
+	handler.RegisterHandler(handler.ItemUseOnBlockHandlerID, protectionHandler{})
+}
+
+// HandleBlockBreak prevents enemies from breaking blocks inside a claim.
+func (protectionHandler) HandleBlockBreak(p *player.Player, ctx *event.Context, pos cube.Pos, _ *[]item.Stack, _ *int) {
+	protect(p, ctx, pos, team.BlockBreakableKeyOption)
+}
+
+// HandleBlockPlace prevents enemies from placing blocks inside a claim.
+func (protectionHandler) HandleBlockPlace(p *player.Player, ctx *event.Context, pos cube.Pos, _ world.Block) {
+	protect(p, ctx, pos, team.BlockPlaceableKeyOption)
+}
+
+// HandleItemUseOnBlock prevents enemies from interacting with doors, chests, buttons and any other
+// activatable block inside a claim, and members without the containers permission from opening containers.
+func (protectionHandler) HandleItemUseOnBlock(p *player.Player, ctx *event.Context, pos cube.Pos, _ cube.Face, _ mgl64.Vec3) {
+	b := p.World().Block(pos)
+	if _, ok := b.(block.Activatable); ok {
+		protect(p, ctx, pos, team.InteractableKeyOption)
+	}
+
+	if _, ok := b.(block.Container); ok {
+		protectContainer(p, ctx, pos)
+	}
+}
+
+// protect cancels the event if the player is not allowed to modify the land at the position.
+func protect(p *player.Player, ctx *event.Context, pos cube.Pos, key string) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if t, ok := service.Team().CanModify(p, pos, key); !ok {
+		ctx.Cancel()
+
+		p.Message(message.ErrLandProtected.Build(service.Team().DisplayName(p, t)))
+	}
+}
+
+// protectContainer cancels the event if the player is a member of the team that owns the land
+// but their role is lower than the role the team requires to open containers.
+func protectContainer(p *player.Player, ctx *event.Context, pos cube.Pos) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	t, ok := service.Team().LookupAt(p.World(), pos.Vec3Centre()).(*team.PlayerTeam)
+	if !ok || t.Member(p.XUID()) == team.Undefined || t.Can(p.XUID(), team.ContainersPermission) {
+		return
+	}
+
+	ctx.Cancel()
+
+	p.Message(message.ErrSelfNoPermission.Build(t.Permission(team.ContainersPermission).Name()))
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/config"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+type pvpTimerHandler struct{}
+
+// RegisterPvPTimerHandler registers the handler that protects the players with a PvP timer.
+// It must be registered before the combat and move handlers, so protected players are never tagged
+// and the territory is not updated when entering enemy land is denied.
+func RegisterPvPTimerHandler() {
+	handler.RegisterHandler(handler.AttackEntityHandlerID, pvpTimerHandler{})
+	handler.RegisterHandler(handler.HurtHandlerID, pvpTimerHandler{})
+	handler.RegisterHandler(handler.MoveHandlerID, pvpTimerHandler{})
+	handler.RegisterHandler(handler.RespawnHandlerID, pvpTimerHandler{})
+}
+
+// HandleAttackEntity prevents melee hits when the attacker or the target has PvP protection.
+func (pvpTimerHandler) HandleAttackEntity(p *player.Player, ctx *event.Context, e world.Entity, _, _ *float64, _ *bool) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if target, ok := e.(*player.Player); ok {
+		if msg, protected := pvpProtected(p, target); protected {
+			ctx.Cancel()
+
+			p.Message(msg)
+		}
+	}
+}
+
+// HandleHurt prevents projectile and melee damage when the attacker or the victim has PvP protection.
+func (pvpTimerHandler) HandleHurt(p *player.Player, ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if attacker, ok := service.AttackerOf(src); ok && attacker != p {
+		if msg, protected := pvpProtected(attacker, p); protected {
+			ctx.Cancel()
+
+			attacker.Message(msg)
+		}
+	}
+}
+
+// HandleMove prevents players with PvP protection from entering the land of an enemy team.
+func (pvpTimerHandler) HandleMove(p *player.Player, ctx *event.Context, newPos mgl64.Vec3, _, _ float64) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	u := service.User().LookupByXUID(p.XUID())
+	if u == nil || !u.PvPProtected() {
+		return
+	}
+
+	t, ok := service.Team().LookupAt(p.World(), newPos).(*team.PlayerTeam)
+	if !ok || t.Member(p.XUID()) != team.Undefined {
+		return
+	}
+
+	if pt := service.Team().LookupByMember(p.XUID()); pt != nil && t.IsAlly(pt.Tracker().Id()) {
+		return
+	}
+
+	// Players standing in the land already, like after a claim, can still walk out of it
+	if service.Team().LookupAt(p.World(), p.Position()) == team.Team(t) {
+		return
+	}
+
+	ctx.Cancel()
+
+	p.Message(message.ErrPvPEnemyLand.Build(service.Team().DisplayName(p, t)))
+}
+
+// HandleRespawn gives the PvP timer back to the player after respawning.
+func (pvpTimerHandler) HandleRespawn(p *player.Player, _ *mgl64.Vec3, _ **world.World) {
+	if u := service.User().LookupByXUID(p.XUID()); u != nil {
+		u.SetPvPTimer(time.Duration(config.CombatConfig().PvPTimer) * time.Second)
+	}
+}
+
+// pvpProtected returns the message for the attacker and true if the attacker or the victim has PvP protection.
+func pvpProtected(attacker, victim *player.Player) (string, bool) {
+	if u := service.User().LookupByXUID(attacker.XUID()); u != nil && u.PvPProtected() {
+		return message.ErrPvPSelfProtected.Build(), true
+	}
+
+	if u := service.User().LookupByXUID(victim.XUID()); u != nil && u.PvPProtected() {
+		return message.ErrPvPPlayerProtected.Build(victim.Name()), true
+	}
+
+	return "", false
+}
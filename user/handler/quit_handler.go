@@ -1,18 +1,37 @@
 package handler
 
 import (
+    "github.com/aabstractt/aurial/handler"
+    "github.com/bitrule/disrupt/message"
     "github.com/bitrule/disrupt/service"
     "github.com/df-mc/dragonfly/server/player"
+    "time"
 )
 
 type quitHandler struct{}
 
+func RegisterQuitHandler() {
+    handler.RegisterHandler(handler.QuitHandlerID, quitHandler{})
+}
+
 func (quitHandler) HandleQuit(p *player.Player) {
     u := service.User().LookupByXUID(p.XUID())
     if u == nil {
         return
     }
 
+    // Players who used the logout countdown leave safely
+    if u.CombatTagged() && !u.SafeLogout() && !p.Dead() {
+        service.Broadcast(message.BroadcastCombatLogged.Build(p.Name()))
+
+        service.CombatLogger().Spawn(p)
+    }
+
+    u.SetLastSeen(time.Now())
+
+    // The PvP timer and the last seen time must survive the relog
+    service.User().SaveAsync(u)
+
     // After the player quits, restore the local user data
     // because the user never is deleted from the service
     u.Restore()
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+type safeZoneHandler struct{}
+
+// RegisterSafeZoneHandler registers the handler that enforces the safe zones.
+// It must be registered before any other hurt handler, so they see the damage cancelled.
+func RegisterSafeZoneHandler() {
+	handler.RegisterHandler(handler.HurtHandlerID, safeZoneHandler{})
+	handler.RegisterHandler(handler.AttackEntityHandlerID, safeZoneHandler{})
+	handler.RegisterHandler(handler.FoodLossHandlerID, safeZoneHandler{})
+	handler.RegisterHandler(handler.ItemDropHandlerID, safeZoneHandler{})
+}
+
+// HandleHurt cancels any damage taken inside a safe zone, including projectiles shot from outside,
+// and any damage dealt by a player standing inside a safe zone.
+func (safeZoneHandler) HandleHurt(p *player.Player, ctx *event.Context, _ *float64, _ *time.Duration, src world.DamageSource) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if inSafeZone(p) {
+		ctx.Cancel()
+	} else if attacker, ok := service.AttackerOf(src); ok && inSafeZone(attacker) {
+		ctx.Cancel()
+	}
+}
+
+// HandleAttackEntity prevents players from hitting others when any of them is inside a safe zone.
+func (safeZoneHandler) HandleAttackEntity(p *player.Player, ctx *event.Context, e world.Entity, _, _ *float64, _ *bool) {
+	if ctx.Cancelled() {
+		return
+	}
+
+	if target, ok := e.(*player.Player); ok && (inSafeZone(p) || inSafeZone(target)) {
+		ctx.Cancel()
+
+		p.Message(message.ErrSafeZoneAttack.Build())
+	}
+}
+
+// HandleFoodLoss prevents players from losing food inside a safe zone.
+func (safeZoneHandler) HandleFoodLoss(p *player.Player, ctx *event.Context, _ int, _ *int) {
+	if inSafeZone(p) {
+		ctx.Cancel()
+	}
+}
+
+// HandleItemDrop prevents players from dropping items inside a safe zone.
+func (safeZoneHandler) HandleItemDrop(p *player.Player, ctx *event.Context, _ world.Entity) {
+	if inSafeZone(p) {
+		ctx.Cancel()
+	}
+}
+
+// inSafeZone returns true if the player is inside the land of a team flagged as safe zone.
+func inSafeZone(p *player.Player) bool {
+	return service.Team().SafeZoneAt(p.World(), p.Position())
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/aabstractt/aurial/handler"
+	"github.com/bitrule/disrupt/message"
+	"github.com/bitrule/disrupt/service"
+	"github.com/bitrule/disrupt/team"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/go-gl/mathgl/mgl64"
+	"strconv"
+)
+
+type wandHandler struct{}
+
+func RegisterWandHandler() {
+	handler.RegisterHandler(handler.StartBreakHandlerID, wandHandler{})
+	handler.RegisterHandler(handler.BlockBreakHandlerID, wandHandler{})
+	handler.RegisterHandler(handler.ItemUseOnBlockHandlerID, wandHandler{})
+}
+
+// HandleStartBreak selects the first corner when the player left clicks a block with the wand.
+func (wandHandler) HandleStartBreak(p *player.Player, ctx *event.Context, pos cube.Pos) {
+	if mainHand, _ := p.HeldItems(); !team.IsClaimWand(mainHand) {
+		return
+	}
+
+	ctx.Cancel()
+
+	if u := service.User().LookupByXUID(p.XUID()); u != nil {
+		u.Selection().SetFirst(p.World(), pos)
+
+		p.Message(message.SuccessSelfClaimCornerSet.Build("first", strconv.Itoa(pos.X()), strconv.Itoa(pos.Z())))
+	}
+}
+
+// HandleBlockBreak prevents the wand from breaking blocks, for example in creative mode.
+func (wandHandler) HandleBlockBreak(p *player.Player, ctx *event.Context, _ cube.Pos, _ *[]item.Stack, _ *int) {
+	if mainHand, _ := p.HeldItems(); team.IsClaimWand(mainHand) {
+		ctx.Cancel()
+	}
+}
+
+// HandleItemUseOnBlock selects the second corner when the player right clicks a block with the wand.
+func (wandHandler) HandleItemUseOnBlock(p *player.Player, ctx *event.Context, pos cube.Pos, _ cube.Face, _ mgl64.Vec3) {
+	if mainHand, _ := p.HeldItems(); !team.IsClaimWand(mainHand) {
+		return
+	}
+
+	ctx.Cancel()
+
+	if u := service.User().LookupByXUID(p.XUID()); u != nil {
+		u.Selection().SetSecond(p.World(), pos)
+
+		p.Message(message.SuccessSelfClaimCornerSet.Build("second", strconv.Itoa(pos.X()), strconv.Itoa(pos.Z())))
+	}
+}
@@ -0,0 +1,68 @@
+package user
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// Selection holds the two corners selected by a user with the claim wand.
+type Selection struct {
+	mu sync.Mutex // Protects the fields below
+
+	w *world.World // World where the corners were selected, the default worlds share their name so it is not compared by name
+
+	first  *cube.Pos
+	second *cube.Pos
+}
+
+// SetFirst sets the first corner of the selection.
+// If the corner is in another world, the second corner is discarded.
+func (s *Selection) SetFirst(w *world.World, pos cube.Pos) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w != w {
+		s.second = nil
+	}
+
+	s.w = w
+	s.first = &pos
+}
+
+// SetSecond sets the second corner of the selection.
+// If the corner is in another world, the first corner is discarded.
+func (s *Selection) SetSecond(w *world.World, pos cube.Pos) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w != w {
+		s.first = nil
+	}
+
+	s.w = w
+	s.second = &pos
+}
+
+// Corners returns the world and both corners of the selection.
+// ok is false if any of the corners is not selected yet.
+func (s *Selection) Corners() (w *world.World, first, second cube.Pos, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.first == nil || s.second == nil {
+		return nil, cube.Pos{}, cube.Pos{}, false
+	}
+
+	return s.w, *s.first, *s.second, true
+}
+
+// Reset discards both corners of the selection.
+func (s *Selection) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.w = nil
+	s.first = nil
+	s.second = nil
+}
@@ -10,6 +10,9 @@ type Tracker struct {
     deaths atomic.Int64
 
     assists atomic.Int64
+
+    streak     atomic.Int64 // Kills in the current life
+    bestStreak atomic.Int64
 }
 
 // Kills returns the number of kills the user has
@@ -42,12 +45,42 @@ func (t *Tracker) IncAssists() {
     t.assists.Add(1)
 }
 
+// Streak returns the number of kills the user has in the current life
+func (t *Tracker) Streak() int64 {
+    return t.streak.Load()
+}
+
+// IncStreak increments the user's killstreak, updating the best streak if it is beaten
+func (t *Tracker) IncStreak() int64 {
+    streak := t.streak.Add(1)
+
+    for {
+        best := t.bestStreak.Load()
+        if streak <= best || t.bestStreak.CompareAndSwap(best, streak) {
+            return streak
+        }
+    }
+}
+
+// ResetStreak resets the user's killstreak after dying
+func (t *Tracker) ResetStreak() {
+    t.streak.Store(0)
+}
+
+// BestStreak returns the highest killstreak the user has reached
+func (t *Tracker) BestStreak() int64 {
+    return t.bestStreak.Load()
+}
+
 // Marshal returns the tracker as a map
 func (t *Tracker) Marshal() (map[string]interface{}, error) {
     return map[string]interface{}{
         "kills":   t.kills.Load(),
         "deaths":  t.deaths.Load(),
         "assists": t.assists.Load(),
+
+        "streak":     t.streak.Load(),
+        "bestStreak": t.bestStreak.Load(),
     }, nil
 }
 
@@ -72,5 +105,14 @@ func (t *Tracker) Unmarshal(body map[string]interface{}) error {
     t.deaths.Store(deaths)
     t.kills.Store(kills)
 
+    // Users saved before killstreaks existed have no streaks
+    if streak, ok := body["streak"].(int64); ok {
+        t.streak.Store(streak)
+    }
+
+    if bestStreak, ok := body["bestStreak"].(int64); ok {
+        t.bestStreak.Store(bestStreak)
+    }
+
     return nil
 }
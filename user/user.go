@@ -2,7 +2,9 @@ package user
 
 import (
     "errors"
+    "sync"
     "sync/atomic"
+    "time"
 )
 
 // New creates an empty user
@@ -21,6 +23,30 @@ type User struct {
     teamChat atomic.Bool
     teamAt   string
 
+    selection Selection
+
+    damageHistory DamageHistory
+
+    combatTagMu    sync.RWMutex // Protects combatTagUntil
+    combatTagUntil time.Time
+
+    combatLoggerKilled atomic.Bool // Whether the combat logger was killed while the user was offline
+
+    pvpTimerMu sync.RWMutex  // Protects pvpTimer
+    pvpTimer   time.Duration // Remaining time of the PvP protection
+
+    deathbanMu    sync.RWMutex // Protects deathbanUntil
+    deathbanUntil time.Time
+
+    lives atomic.Int32
+
+    lastSeenMu sync.RWMutex // Protects lastSeen
+    lastSeen   time.Time    // Last time the user joined or quit the server
+
+    home       Countdown
+    logout     Countdown
+    safeLogout atomic.Bool // Whether the user logged out using the logout countdown
+
     tracker *Tracker
 }
 
@@ -54,6 +80,185 @@ func (u *User) SetTeamAt(team string) {
     u.teamAt = team
 }
 
+// Selection returns the user's claim wand selection
+func (u *User) Selection() *Selection {
+    return &u.selection
+}
+
+// DamageHistory returns the recent hits other players dealt to the user
+func (u *User) DamageHistory() *DamageHistory {
+    return &u.damageHistory
+}
+
+// CombatTagged returns true if the user is in combat
+func (u *User) CombatTagged() bool {
+    return u.CombatTagRemaining() > 0
+}
+
+// CombatTagRemaining returns the remaining time until the user is no longer in combat
+func (u *User) CombatTagRemaining() time.Duration {
+    u.combatTagMu.RLock()
+    defer u.combatTagMu.RUnlock()
+
+    return max(time.Until(u.combatTagUntil), 0)
+}
+
+// SetCombatTag tags the user in combat for the given duration
+func (u *User) SetCombatTag(d time.Duration) {
+    u.combatTagMu.Lock()
+    u.combatTagUntil = time.Now().Add(d)
+    u.combatTagMu.Unlock()
+}
+
+// ClearCombatTag removes the user from combat
+func (u *User) ClearCombatTag() {
+    u.combatTagMu.Lock()
+    u.combatTagUntil = time.Time{}
+    u.combatTagMu.Unlock()
+}
+
+// CombatLoggerKilled returns true if the user's combat logger was killed while they were offline
+func (u *User) CombatLoggerKilled() bool {
+    return u.combatLoggerKilled.Load()
+}
+
+// SetCombatLoggerKilled sets if the user's combat logger was killed while they were offline
+func (u *User) SetCombatLoggerKilled(v bool) {
+    u.combatLoggerKilled.Store(v)
+}
+
+// PvPTimer returns the remaining time of the user's PvP protection
+func (u *User) PvPTimer() time.Duration {
+    u.pvpTimerMu.RLock()
+    defer u.pvpTimerMu.RUnlock()
+
+    return u.pvpTimer
+}
+
+// SetPvPTimer sets the remaining time of the user's PvP protection, zero removes it
+func (u *User) SetPvPTimer(d time.Duration) {
+    u.pvpTimerMu.Lock()
+    u.pvpTimer = max(d, 0)
+    u.pvpTimerMu.Unlock()
+}
+
+// PvPProtected returns true if the user has PvP protection
+func (u *User) PvPProtected() bool {
+    return u.PvPTimer() > 0
+}
+
+// TickPvPTimer takes the elapsed time away from the user's PvP protection.
+// Returns true if the protection has just expired.
+func (u *User) TickPvPTimer(elapsed time.Duration) bool {
+    u.pvpTimerMu.Lock()
+    defer u.pvpTimerMu.Unlock()
+
+    if u.pvpTimer <= 0 {
+        return false
+    }
+
+    u.pvpTimer = max(u.pvpTimer-elapsed, 0)
+
+    return u.pvpTimer == 0
+}
+
+// Deathbanned returns true if the user is banned from the map after dying
+func (u *User) Deathbanned() bool {
+    return u.DeathbanRemaining() > 0
+}
+
+// DeathbanRemaining returns the remaining time until the user's deathban ends
+func (u *User) DeathbanRemaining() time.Duration {
+    u.deathbanMu.RLock()
+    defer u.deathbanMu.RUnlock()
+
+    return max(time.Until(u.deathbanUntil), 0)
+}
+
+// SetDeathban bans the user from the map for the given duration
+func (u *User) SetDeathban(d time.Duration) {
+    u.deathbanMu.Lock()
+    u.deathbanUntil = time.Now().Add(d)
+    u.deathbanMu.Unlock()
+}
+
+// ClearDeathban removes the user's deathban, returns false if the user was not banned
+func (u *User) ClearDeathban() bool {
+    u.deathbanMu.Lock()
+    defer u.deathbanMu.Unlock()
+
+    banned := time.Now().Before(u.deathbanUntil)
+    u.deathbanUntil = time.Time{}
+
+    return banned
+}
+
+// deathbanUntilMillis returns the end of the user's deathban as milliseconds, zero if the user is not banned
+func (u *User) deathbanUntilMillis() int64 {
+    u.deathbanMu.RLock()
+    defer u.deathbanMu.RUnlock()
+
+    if u.deathbanUntil.IsZero() {
+        return 0
+    }
+
+    return u.deathbanUntil.UnixMilli()
+}
+
+// Lives returns the lives the user has to revive themselves or others
+func (u *User) Lives() int32 {
+    return u.lives.Load()
+}
+
+// AddLives adds the given amount to the user's lives, a negative amount takes them away
+func (u *User) AddLives(amount int32) int32 {
+    return u.lives.Add(amount)
+}
+
+// LastSeen returns the last time the user joined or quit the server, zero if it is unknown
+func (u *User) LastSeen() time.Time {
+    u.lastSeenMu.RLock()
+    defer u.lastSeenMu.RUnlock()
+
+    return u.lastSeen
+}
+
+// SetLastSeen sets the last time the user joined or quit the server
+func (u *User) SetLastSeen(t time.Time) {
+    u.lastSeenMu.Lock()
+    u.lastSeen = t
+    u.lastSeenMu.Unlock()
+}
+
+// lastSeenMillis returns the last time the user was seen as milliseconds, zero if it is unknown
+func (u *User) lastSeenMillis() int64 {
+    if lastSeen := u.LastSeen(); !lastSeen.IsZero() {
+        return lastSeen.UnixMilli()
+    }
+
+    return 0
+}
+
+// Home returns the user's teleport home countdown
+func (u *User) Home() *Countdown {
+    return &u.home
+}
+
+// Logout returns the user's logout countdown
+func (u *User) Logout() *Countdown {
+    return &u.logout
+}
+
+// SafeLogout returns true if the user logged out using the logout countdown
+func (u *User) SafeLogout() bool {
+    return u.safeLogout.Load()
+}
+
+// SetSafeLogout sets if the user logged out using the logout countdown
+func (u *User) SetSafeLogout(v bool) {
+    u.safeLogout.Store(v)
+}
+
 // Tracker returns the user's tracker
 func (u *User) Tracker() *Tracker {
     return u.tracker
@@ -62,11 +267,17 @@ func (u *User) Tracker() *Tracker {
 // Restore restores the user's state
 func (u *User) Restore() {
     u.teamChat.Store(false)
+    u.selection.Reset()
+    u.ClearCombatTag()
+    u.damageHistory.Clear()
+    u.home.Cancel()
+    u.logout.Cancel()
+    u.safeLogout.Store(false)
 }
 
 // Unmarshal unmarshals the user from a map
 func (u *User) Unmarshal(body map[string]interface{}) error {
-    xuid, ok := body["xuid"].(string)
+    xuid, ok := body["_id"].(string)
     if !ok {
         return errors.New("missing user XUID")
     }
@@ -90,6 +301,30 @@ func (u *User) Unmarshal(body map[string]interface{}) error {
 
     u.tracker = tracker
 
+    // Users saved before the PvP timer existed have no protection
+    if pvpTimer, ok := body["pvpTimer"].(int64); ok {
+        u.pvpTimer = time.Duration(pvpTimer) * time.Millisecond
+    }
+
+    // Users saved before deathbans existed are not banned and have no lives
+    if deathbanUntil, ok := body["deathbanUntil"].(int64); ok && deathbanUntil > 0 {
+        u.deathbanUntil = time.UnixMilli(deathbanUntil)
+    }
+
+    if lives, ok := body["lives"].(int32); ok {
+        u.lives.Store(lives)
+    }
+
+    // Users saved before the last seen time existed are never considered inactive
+    if lastSeen, ok := body["lastSeen"].(int64); ok && lastSeen > 0 {
+        u.lastSeen = time.UnixMilli(lastSeen)
+    }
+
+    // Users saved before combat loggers existed have no flag
+    if killed, ok := body["combatLoggerKilled"].(bool); ok {
+        u.combatLoggerKilled.Store(killed)
+    }
+
     return nil
 }
 
@@ -104,5 +339,11 @@ func (u *User) Marshal() (map[string]interface{}, error) {
         "_id":     u.xuid,
         "name":    u.name,
         "tracker": trackMarshal,
+
+        "pvpTimer":           u.PvPTimer().Milliseconds(),
+        "deathbanUntil":      u.deathbanUntilMillis(),
+        "lives":              u.lives.Load(),
+        "combatLoggerKilled": u.combatLoggerKilled.Load(),
+        "lastSeen":           u.lastSeenMillis(),
     }, nil
 }